@@ -0,0 +1,94 @@
+package benchmarks_test
+
+import (
+	"testing"
+
+	"github.com/reugn/async"
+)
+
+var chainLength = 10
+
+func increment(v int) (int, error) {
+	return v + 1, nil
+}
+
+// goroutineMap mimics the goroutine per stage combinator implementation,
+// where each stage blocks a goroutine until the source Future is completed.
+func goroutineMap(future async.Future[int], f func(int) (int, error)) async.Future[int] {
+	promise := async.NewPromise[int]()
+	go func() {
+		value, err := future.Join()
+		if err != nil {
+			promise.Failure(err)
+			return
+		}
+		value, err = f(value)
+		if err != nil {
+			promise.Failure(err)
+		} else {
+			promise.Success(value)
+		}
+	}()
+	return promise.Future()
+}
+
+// go test -bench=FutureChain -benchmem -v.
+func BenchmarkFutureChain_Goroutine(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		promise := async.NewPromise[int]()
+		future := promise.Future()
+		for range chainLength {
+			future = goroutineMap(future, increment)
+		}
+		promise.Success(0)
+		_, _ = future.Join()
+	}
+}
+
+func BenchmarkFutureChain_Callback(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		promise := async.NewPromise[int]()
+		future := promise.Future()
+		for range chainLength {
+			future = future.Map(increment)
+		}
+		promise.Success(0)
+		_, _ = future.Join()
+	}
+}
+
+func BenchmarkFutureChain_GoroutinePending(b *testing.B) {
+	b.ReportAllocs()
+	promises := make([]async.Promise[int], 0, b.N)
+	for range b.N {
+		promise := async.NewPromise[int]()
+		future := promise.Future()
+		for range chainLength {
+			future = goroutineMap(future, increment)
+		}
+		promises = append(promises, promise)
+	}
+	b.StopTimer()
+	for _, promise := range promises {
+		promise.Success(0)
+	}
+}
+
+func BenchmarkFutureChain_CallbackPending(b *testing.B) {
+	b.ReportAllocs()
+	promises := make([]async.Promise[int], 0, b.N)
+	for range b.N {
+		promise := async.NewPromise[int]()
+		future := promise.Future()
+		for range chainLength {
+			future = future.Map(increment)
+		}
+		promises = append(promises, promise)
+	}
+	b.StopTimer()
+	for _, promise := range promises {
+		promise.Success(0)
+	}
+}
@@ -0,0 +1,126 @@
+package async
+
+import (
+	"context"
+	"iter"
+	"sync"
+	"sync/atomic"
+)
+
+// Completion holds the Result of a completed Future along with the index
+// of the Future in the input list.
+type Completion[T any] struct {
+	Index  int
+	Result Result[T]
+}
+
+// FutureCompletions returns an iterator over the results of the given Futures
+// in the order of their completion, yielding the index of each Future along
+// with its Result. Breaking out of the iteration does not affect the Futures.
+func FutureCompletions[T any](futures ...Future[T]) iter.Seq2[int, Result[T]] {
+	return func(yield func(int, Result[T]) bool) {
+		for completion := range FutureCompletionChan(futures...) {
+			if !yield(completion.Index, completion.Result) {
+				return
+			}
+		}
+	}
+}
+
+// FutureCompletionChan returns a channel that receives the results of the
+// given Futures in the order of their completion. The channel is buffered
+// to hold all the results and is closed once all the Futures are completed.
+func FutureCompletionChan[T any](futures ...Future[T]) <-chan Completion[T] {
+	ch := make(chan Completion[T], len(futures))
+	if len(futures) == 0 {
+		close(ch)
+		return ch
+	}
+	var remaining atomic.Int64
+	remaining.Store(int64(len(futures)))
+	for i, future := range futures {
+		future.OnComplete(func(value T, err error) {
+			ch <- Completion[T]{Index: i, Result: NewResult(value, err)}
+			if remaining.Add(-1) == 0 {
+				close(ch)
+			}
+		})
+	}
+	return ch
+}
+
+// CompletionService submits tasks to an [ExecutorService] and makes their
+// Futures available in the order of completion.
+type CompletionService[T any] struct {
+	executor  ExecutorService[T]
+	mtx       sync.Mutex
+	completed []Future[T]
+	signal    chan struct{}
+}
+
+// NewCompletionService returns a new [CompletionService] using the given
+// executor to run the submitted tasks.
+func NewCompletionService[T any](executor ExecutorService[T]) *CompletionService[T] {
+	return &CompletionService[T]{
+		executor: executor,
+		signal:   make(chan struct{}, 1),
+	}
+}
+
+// Submit submits a function to the underlying executor.
+// The returned Future will also be available via Take and Poll once
+// it is completed.
+func (cs *CompletionService[T]) Submit(f func(context.Context) (T, error)) (Future[T], error) {
+	future, err := cs.executor.Submit(f)
+	if err != nil {
+		return nil, err
+	}
+	future.OnComplete(func(_ T, _ error) {
+		cs.mtx.Lock()
+		cs.completed = append(cs.completed, future)
+		cs.mtx.Unlock()
+		cs.notify()
+	})
+	return future, nil
+}
+
+// Take blocks until a submitted Future is completed or the context is
+// canceled, and returns the Future, removing it from the service.
+func (cs *CompletionService[T]) Take(ctx context.Context) (Future[T], error) {
+	for {
+		if future, ok := cs.Poll(); ok {
+			return future, nil
+		}
+		select {
+		case <-cs.signal:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// Poll returns the next completed Future without blocking, removing it from
+// the service. The second return value reports whether there was one.
+func (cs *CompletionService[T]) Poll() (Future[T], bool) {
+	cs.mtx.Lock()
+	defer cs.mtx.Unlock()
+	if len(cs.completed) == 0 {
+		return nil, false
+	}
+	future := cs.completed[0]
+	cs.completed[0] = nil
+	cs.completed = cs.completed[1:]
+	if len(cs.completed) > 0 {
+		// wake up another waiting taker
+		cs.notify()
+	}
+	return future, true
+}
+
+// notify signals a waiting taker without blocking.
+func (cs *CompletionService[T]) notify() {
+	select {
+	case cs.signal <- struct{}{}:
+	default:
+	}
+}
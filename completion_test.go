@@ -0,0 +1,87 @@
+package async_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/reugn/async"
+
+	"github.com/reugn/async/internal/assert"
+)
+
+func TestFutureCompletions(t *testing.T) {
+	p1 := async.NewPromise[int]()
+	p2 := async.NewPromise[int]()
+	p3 := async.NewPromise[int]()
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		p3.Success(3)
+		time.Sleep(10 * time.Millisecond)
+		p1.Failure(errors.New("error"))
+		time.Sleep(10 * time.Millisecond)
+		p2.Success(2)
+	}()
+
+	var indices []int
+	for i, result := range async.FutureCompletions(p1.Future(), p2.Future(), p3.Future()) {
+		indices = append(indices, i)
+		if i == 0 {
+			assert.ErrorContains(t, result.Err(), "error")
+		}
+	}
+	assert.Equal(t, []int{2, 0, 1}, indices)
+
+	// break out of the iteration
+	for i := range async.FutureCompletions(p1.Future(), p2.Future()) {
+		assert.Equal(t, 0, i)
+		break
+	}
+}
+
+func TestFutureCompletionChan(t *testing.T) {
+	ch := async.FutureCompletionChan(async.Completed(1), async.Completed(2))
+	var sum int
+	for completion := range ch {
+		value, err := completion.Result.Unwrap()
+		assert.IsNil(t, err)
+		sum += value
+	}
+	assert.Equal(t, 3, sum)
+
+	_, ok := <-async.FutureCompletionChan[int]()
+	assert.Equal(t, false, ok)
+}
+
+func TestCompletionService(t *testing.T) {
+	executor := async.NewExecutor[int](t.Context(), async.NewExecutorConfig(2, 2))
+	defer func() { _ = executor.Shutdown() }()
+	cs := async.NewCompletionService[int](executor)
+
+	_, ok := cs.Poll()
+	assert.Equal(t, false, ok)
+
+	slow, err := cs.Submit(func(_ context.Context) (int, error) {
+		time.Sleep(20 * time.Millisecond)
+		return 1, nil
+	})
+	assert.IsNil(t, err)
+	fast, err := cs.Submit(func(_ context.Context) (int, error) {
+		return 2, nil
+	})
+	assert.IsNil(t, err)
+
+	future, err := cs.Take(t.Context())
+	assert.IsNil(t, err)
+	assert.Equal(t, fast, future)
+
+	future, err = cs.Take(t.Context())
+	assert.IsNil(t, err)
+	assert.Equal(t, slow, future)
+
+	ctx, cancel := context.WithTimeout(t.Context(), time.Millisecond)
+	defer cancel()
+	_, err = cs.Take(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
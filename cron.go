@@ -0,0 +1,495 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrSchedulerStopped is returned when scheduling a job on a stopped
+// [CronScheduler].
+var ErrSchedulerStopped = errors.New("async: scheduler is stopped")
+
+// CronSchedule represents a parsed cron expression.
+type CronSchedule struct {
+	second, minute, hour, dom, month, dow uint64
+	// domStar and dowStar report whether the day fields are unrestricted,
+	// which defines how the day of month and day of week are combined.
+	domStar, dowStar bool
+	// fixedTime reports whether none of the time of day fields is a
+	// wildcard, in which case the activations repeated by the clocks
+	// turned back on a daylight saving transition are skipped.
+	fixedTime bool
+	// location is the time zone of the schedule; if nil, the location of
+	// the time passed to Next is used.
+	location *time.Location
+}
+
+// cronField defines the bounds and the names of a cron expression field.
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	secondField = cronField{name: "second", min: 0, max: 59}
+	minuteField = cronField{name: "minute", min: 0, max: 59}
+	hourField   = cronField{name: "hour", min: 0, max: 23}
+	domField    = cronField{name: "day of month", min: 1, max: 31}
+	monthField  = cronField{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// day of week 7 is an alias for Sunday
+	dowField = cronField{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 0 1 1 *",
+	"@annually": "0 0 0 1 1 *",
+	"@monthly":  "0 0 0 1 * *",
+	"@weekly":   "0 0 0 * * 0",
+	"@daily":    "0 0 0 * * *",
+	"@midnight": "0 0 0 * * *",
+	"@hourly":   "0 0 * * * *",
+}
+
+// ParseCronSchedule parses a cron expression.
+//
+// The expression consists of either 5 fields (minute, hour, day of month,
+// month, day of week) or 6 fields, with a leading seconds field. A field may
+// contain a wildcard (*, or ? for the day fields), a value, a range (a-b)
+// and a step (*/n, a/n or a-b/n), or a comma-separated list of those.
+// Months and days of week can also be specified by their three-letter
+// English names. Descriptors such as @hourly, @daily, @weekly, @monthly and
+// @yearly are supported as well.
+//
+// The expression can be prefixed with CRON_TZ=<zone> or TZ=<zone> to
+// evaluate the schedule in the given time zone, e.g.
+// "CRON_TZ=Europe/Berlin 0 3 * * *".
+func ParseCronSchedule(spec string) (*CronSchedule, error) {
+	expr := strings.TrimSpace(spec)
+	var location *time.Location
+	if strings.HasPrefix(expr, "CRON_TZ=") || strings.HasPrefix(expr, "TZ=") {
+		zone, rest, _ := strings.Cut(expr, " ")
+		_, name, _ := strings.Cut(zone, "=")
+		var err error
+		if location, err = time.LoadLocation(name); err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %w", spec, err)
+		}
+		expr = strings.TrimSpace(rest)
+	}
+	if strings.HasPrefix(expr, "@") {
+		descriptor, ok := cronDescriptors[strings.ToLower(expr)]
+		if !ok {
+			return nil, fmt.Errorf("invalid cron expression %q: unknown descriptor", spec)
+		}
+		expr = descriptor
+	}
+
+	fields := strings.Fields(expr)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, fmt.Errorf("invalid cron expression %q: expected 5 or 6 fields, got %d",
+			spec, len(fields))
+	}
+
+	schedule := &CronSchedule{
+		location: location,
+		domStar:  isCronWildcard(fields[3]),
+		dowStar:  isCronWildcard(fields[5]),
+		fixedTime: !isCronWildcardBased(fields[0]) && !isCronWildcardBased(fields[1]) &&
+			!isCronWildcardBased(fields[2]),
+	}
+	bits := []*uint64{&schedule.second, &schedule.minute, &schedule.hour,
+		&schedule.dom, &schedule.month, &schedule.dow}
+	for i, field := range []cronField{secondField, minuteField, hourField,
+		domField, monthField, dowField} {
+		var err error
+		if *bits[i], err = field.parse(fields[i]); err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %w", spec, err)
+		}
+	}
+	if schedule.dow&(1<<7) != 0 {
+		schedule.dow |= 1
+	}
+	return schedule, nil
+}
+
+// isCronWildcard reports whether a field matches any value.
+func isCronWildcard(field string) bool {
+	return field == "*" || field == "?"
+}
+
+// isCronWildcardBased reports whether a field is a wildcard, possibly
+// with a step, e.g. "*/15".
+func isCronWildcardBased(field string) bool {
+	return strings.HasPrefix(field, "*") || strings.HasPrefix(field, "?")
+}
+
+// parse parses a comma-separated list of field values into a bit set.
+func (f cronField) parse(field string) (uint64, error) {
+	var bits uint64
+	for part := range strings.SplitSeq(field, ",") {
+		partBits, err := f.parseRange(part)
+		if err != nil {
+			return 0, err
+		}
+		bits |= partBits
+	}
+	return bits, nil
+}
+
+// parseRange parses a single field value, range or step into a bit set.
+func (f cronField) parseRange(part string) (uint64, error) {
+	rangePart, stepPart, hasStep := strings.Cut(part, "/")
+	step := 1
+	if hasStep {
+		var err error
+		if step, err = strconv.Atoi(stepPart); err != nil || step <= 0 {
+			return 0, fmt.Errorf("invalid %s step %q", f.name, stepPart)
+		}
+	}
+
+	var start, end int
+	if isCronWildcard(rangePart) {
+		start, end = f.min, f.max
+	} else {
+		low, high, isRange := strings.Cut(rangePart, "-")
+		var err error
+		if start, err = f.value(low); err != nil {
+			return 0, err
+		}
+		switch {
+		case isRange:
+			if end, err = f.value(high); err != nil {
+				return 0, err
+			}
+		case hasStep:
+			end = f.max
+		default:
+			end = start
+		}
+		if start > end {
+			return 0, fmt.Errorf("invalid %s range %q", f.name, rangePart)
+		}
+	}
+
+	var bits uint64
+	for i := start; i <= end; i += step {
+		bits |= 1 << i
+	}
+	return bits, nil
+}
+
+// value parses a single numeric or named field value.
+func (f cronField) value(s string) (int, error) {
+	if n, ok := f.names[strings.ToLower(s)]; ok {
+		return n, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < f.min || n > f.max {
+		return 0, fmt.Errorf("invalid %s value %q", f.name, s)
+	}
+	return n, nil
+}
+
+// Location returns the time zone of the schedule, or nil if the schedule
+// is evaluated in the location of the time passed to Next.
+func (s *CronSchedule) Location() *time.Location {
+	return s.location
+}
+
+// Next returns the first activation time of the schedule strictly after t,
+// in the location of t. It returns the zero time if no activation time can
+// be found within five years, e.g. for the 30th of February.
+// A schedule without wildcards in the time of day fields is activated only
+// once when the clocks are turned back on a daylight saving transition.
+func (s *CronSchedule) Next(t time.Time) time.Time {
+	origLocation := t.Location()
+	location := origLocation
+	if s.location != nil {
+		location = s.location
+	}
+	t = t.In(location)
+	t = t.Add(time.Second - time.Duration(t.Nanosecond()))
+
+	limit := t.Year() + 5
+	for t.Year() <= limit {
+		switch {
+		case !hasBit(s.month, int(t.Month())):
+			t = advancePast(t, time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, location))
+		case !s.dayMatches(t):
+			t = advancePast(t, time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, location))
+		case !hasBit(s.hour, t.Hour()):
+			// advance by the absolute duration to move across the daylight
+			// saving transitions
+			t = t.Add(time.Hour - time.Duration(t.Minute())*time.Minute -
+				time.Duration(t.Second())*time.Second)
+		case !hasBit(s.minute, t.Minute()):
+			t = t.Add(time.Minute - time.Duration(t.Second())*time.Second)
+		case !hasBit(s.second, t.Second()):
+			t = t.Add(time.Second)
+		case s.fixedTime && repeated(t):
+			t = t.Add(time.Second)
+		default:
+			return t.In(origLocation)
+		}
+	}
+	return time.Time{}
+}
+
+// repeated reports whether the wall clock time of t has already occurred
+// earlier, because the clocks were turned back on a daylight saving
+// transition.
+func repeated(t time.Time) bool {
+	_, offset := t.Zone()
+	// the clocks are never turned back by more than a few hours
+	_, earlierOffset := t.Add(-4 * time.Hour).Zone()
+	if earlierOffset <= offset {
+		return false
+	}
+	earlier := t.Add(-time.Duration(earlierOffset-offset) * time.Second)
+	return earlier.Day() == t.Day() && earlier.Hour() == t.Hour() &&
+		earlier.Minute() == t.Minute() && earlier.Second() == t.Second()
+}
+
+// advancePast returns next, moved forward by whole hours if it is not after t.
+// This happens when midnight is skipped by a daylight saving transition
+// and time.Date normalizes it to the previous day.
+func advancePast(t, next time.Time) time.Time {
+	for !next.After(t) {
+		next = next.Add(time.Hour)
+	}
+	return next
+}
+
+// dayMatches reports whether the day of t satisfies the day of month and
+// the day of week fields. If both fields are restricted, either of them
+// has to match.
+func (s *CronSchedule) dayMatches(t time.Time) bool {
+	domMatch := hasBit(s.dom, t.Day())
+	dowMatch := hasBit(s.dow, int(t.Weekday()))
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+func hasBit(bits uint64, i int) bool {
+	return bits&(1<<i) != 0
+}
+
+// CronSchedulerConfig represents the CronScheduler configuration.
+type CronSchedulerConfig struct {
+	// Location is the time zone in which schedules without an explicit
+	// time zone are evaluated. If nil, time.Local is used.
+	Location *time.Location
+	// PreventOverlap skips a run of a job if its previous run is still
+	// in progress.
+	PreventOverlap bool
+}
+
+// NewCronSchedulerConfig returns a new [CronSchedulerConfig].
+func NewCronSchedulerConfig(location *time.Location, preventOverlap bool) *CronSchedulerConfig {
+	return &CronSchedulerConfig{
+		Location:       location,
+		PreventOverlap: preventOverlap,
+	}
+}
+
+// CronScheduler submits jobs to an [ExecutorService] according to their
+// cron schedules. Each job is driven by a stoppable timer, armed for its
+// next activation time only.
+type CronScheduler[T any] struct {
+	executor       ExecutorService[T]
+	location       *time.Location
+	preventOverlap bool
+	mtx            sync.Mutex
+	jobs           map[*CronJob[T]]struct{}
+	stopped        bool
+}
+
+// CronJob represents a job scheduled by a [CronScheduler].
+type CronJob[T any] struct {
+	scheduler *CronScheduler[T]
+	schedule  *CronSchedule
+	task      func(context.Context) (T, error)
+	mtx       sync.Mutex
+	timer     *time.Timer
+	next      time.Time
+	running   Future[T]
+	stopped   bool
+}
+
+// NewCronScheduler returns a new [CronScheduler] submitting jobs to the
+// given executor.
+func NewCronScheduler[T any](executor ExecutorService[T],
+	config *CronSchedulerConfig,
+) *CronScheduler[T] {
+	location := config.Location
+	if location == nil {
+		location = time.Local
+	}
+	return &CronScheduler[T]{
+		executor:       executor,
+		location:       location,
+		preventOverlap: config.PreventOverlap,
+		jobs:           make(map[*CronJob[T]]struct{}),
+	}
+}
+
+// Schedule parses the cron expression and schedules the function to be
+// submitted to the executor at each activation time.
+// See [ParseCronSchedule] for the expression format.
+func (c *CronScheduler[T]) Schedule(spec string,
+	f func(context.Context) (T, error),
+) (*CronJob[T], error) {
+	schedule, err := ParseCronSchedule(spec)
+	if err != nil {
+		return nil, err
+	}
+	return c.ScheduleCron(schedule, f)
+}
+
+// ScheduleCron schedules the function to be submitted to the executor at
+// each activation time of the schedule.
+func (c *CronScheduler[T]) ScheduleCron(schedule *CronSchedule,
+	f func(context.Context) (T, error),
+) (*CronJob[T], error) {
+	job := &CronJob[T]{
+		scheduler: c,
+		schedule:  schedule,
+		task:      f,
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if c.stopped {
+		return nil, ErrSchedulerStopped
+	}
+	c.jobs[job] = struct{}{}
+	job.mtx.Lock()
+	job.scheduleNext(time.Now())
+	job.mtx.Unlock()
+	return job, nil
+}
+
+// Jobs returns the currently scheduled jobs.
+func (c *CronScheduler[T]) Jobs() []*CronJob[T] {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	jobs := make([]*CronJob[T], 0, len(c.jobs))
+	for job := range c.jobs {
+		jobs = append(jobs, job)
+	}
+	return jobs
+}
+
+// Stop stops all the scheduled jobs. Runs in progress are not affected,
+// and the underlying executor is not shut down.
+func (c *CronScheduler[T]) Stop() {
+	c.mtx.Lock()
+	c.stopped = true
+	jobs := c.jobs
+	c.jobs = make(map[*CronJob[T]]struct{})
+	c.mtx.Unlock()
+
+	for job := range jobs {
+		job.stop()
+	}
+}
+
+// Schedule returns the cron schedule of the job.
+func (j *CronJob[T]) Schedule() *CronSchedule {
+	return j.schedule
+}
+
+// Next returns the next activation time of the job, or the zero time if
+// the job is stopped or has no more activation times.
+func (j *CronJob[T]) Next() time.Time {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+	if j.stopped {
+		return time.Time{}
+	}
+	return j.next
+}
+
+// Stop stops subsequent runs of the job. A run in progress is not affected.
+func (j *CronJob[T]) Stop() {
+	j.scheduler.mtx.Lock()
+	delete(j.scheduler.jobs, j)
+	j.scheduler.mtx.Unlock()
+
+	j.stop()
+}
+
+func (j *CronJob[T]) stop() {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+	j.stopped = true
+	if j.timer != nil {
+		j.timer.Stop()
+	}
+}
+
+// run submits the job to the executor and schedules the next run.
+// A run rejected by the executor is skipped; the job is stopped once
+// the executor is shut down.
+func (j *CronJob[T]) run() {
+	j.mtx.Lock()
+	if j.stopped {
+		j.mtx.Unlock()
+		return
+	}
+	overlap := j.scheduler.preventOverlap && j.running != nil && !j.running.IsDone()
+	j.mtx.Unlock()
+
+	if !overlap {
+		future, err := j.scheduler.executor.Submit(j.task)
+		if errors.Is(err, ErrExecutorShutDown) {
+			j.Stop()
+			return
+		}
+		if err == nil {
+			j.mtx.Lock()
+			j.running = future
+			j.mtx.Unlock()
+		}
+	}
+
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+	// the timer is driven by the monotonic clock, so the wall clock may be
+	// behind the activation time, which must not be activated again
+	now := time.Now()
+	if now.Before(j.next) {
+		now = j.next
+	}
+	j.scheduleNext(now)
+}
+
+// scheduleNext arms the timer for the next activation time after now.
+// The job mutex must be held.
+func (j *CronJob[T]) scheduleNext(now time.Time) {
+	if j.stopped {
+		return
+	}
+	j.next = j.schedule.Next(now.In(j.scheduler.location))
+	if j.next.IsZero() {
+		j.stopped = true
+		return
+	}
+	j.timer = time.AfterFunc(time.Until(j.next), j.run)
+}
@@ -0,0 +1,156 @@
+package async_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/reugn/async"
+
+	"github.com/reugn/async/internal/assert"
+)
+
+func TestCronSchedule_Next(t *testing.T) {
+	from := time.Date(2024, time.January, 15, 10, 30, 15, 500, time.UTC)
+	tests := []struct {
+		spec     string
+		expected time.Time
+	}{
+		{"* * * * *", time.Date(2024, time.January, 15, 10, 31, 0, 0, time.UTC)},
+		{"* * * * * *", time.Date(2024, time.January, 15, 10, 30, 16, 0, time.UTC)},
+		{"*/20 * * * * *", time.Date(2024, time.January, 15, 10, 30, 20, 0, time.UTC)},
+		{"0 12 * * *", time.Date(2024, time.January, 15, 12, 0, 0, 0, time.UTC)},
+		{"0 9 * * *", time.Date(2024, time.January, 16, 9, 0, 0, 0, time.UTC)},
+		{"15,45 9-17/2 * * *", time.Date(2024, time.January, 15, 11, 15, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 feb *", time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * fri", time.Date(2024, time.January, 19, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2024, time.January, 21, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 * mon", time.Date(2024, time.January, 22, 0, 0, 0, 0, time.UTC)},
+		{"0 0 ? JUN-AUG *", time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2024, time.January, 15, 11, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2024, time.January, 16, 0, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2024, time.January, 21, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{"@yearly", time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 *", time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			schedule, err := async.ParseCronSchedule(tt.spec)
+			assert.IsNil(t, err)
+			assert.Equal(t, tt.expected, schedule.Next(from))
+		})
+	}
+}
+
+func TestCronSchedule_TimeZone(t *testing.T) {
+	location, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("time zone database is not available")
+	}
+	schedule, err := async.ParseCronSchedule("CRON_TZ=America/New_York 0 3 * * *")
+	assert.IsNil(t, err)
+	assert.Equal(t, location, schedule.Location())
+
+	from := time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)
+	next := schedule.Next(from)
+	assert.Equal(t, time.UTC, next.Location())
+	assert.Equal(t, time.Date(2024, time.January, 15, 8, 0, 0, 0, time.UTC), next)
+
+	// the nonexistent time on the daylight saving transition is skipped
+	schedule, err = async.ParseCronSchedule("TZ=America/New_York 30 2 * * *")
+	assert.IsNil(t, err)
+	from = time.Date(2024, time.March, 10, 0, 0, 0, 0, location)
+	assert.Equal(t, time.Date(2024, time.March, 11, 2, 30, 0, 0, location),
+		schedule.Next(from))
+
+	// the repeated time on the daylight saving transition is activated once
+	schedule, err = async.ParseCronSchedule("CRON_TZ=America/New_York 30 1 * * *")
+	assert.IsNil(t, err)
+	from = time.Date(2024, time.November, 3, 0, 0, 0, 0, location)
+	next = schedule.Next(from)
+	assert.Equal(t, time.Date(2024, time.November, 3, 5, 30, 0, 0, time.UTC), next.UTC())
+	assert.Equal(t, time.Date(2024, time.November, 4, 1, 30, 0, 0, location),
+		schedule.Next(next))
+
+	// a fixed time listed among several hours is activated once as well
+	schedule, err = async.ParseCronSchedule("CRON_TZ=America/New_York 30 1,13 * * *")
+	assert.IsNil(t, err)
+	next = schedule.Next(from)
+	assert.Equal(t, time.Date(2024, time.November, 3, 5, 30, 0, 0, time.UTC), next.UTC())
+	assert.Equal(t, time.Date(2024, time.November, 3, 13, 30, 0, 0, location),
+		schedule.Next(next))
+
+	// a schedule with multiple activations per hour keeps both runs
+	schedule, err = async.ParseCronSchedule("CRON_TZ=America/New_York */30 1 * * *")
+	assert.IsNil(t, err)
+	next = schedule.Next(time.Date(2024, time.November, 3, 5, 30, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2024, time.November, 3, 6, 0, 0, 0, time.UTC), next.UTC())
+}
+
+func TestCronSchedule_Invalid(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"* * * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"@every",
+		"TZ=Invalid/Zone * * * * *",
+	} {
+		_, err := async.ParseCronSchedule(spec)
+		assert.ErrorContains(t, err, "invalid cron expression")
+	}
+}
+
+func TestCronScheduler(t *testing.T) {
+	executor := async.NewExecutor[int](t.Context(), async.NewExecutorConfig(2, 2))
+	defer func() { _ = executor.Shutdown() }()
+	scheduler := async.NewCronScheduler[int](executor,
+		async.NewCronSchedulerConfig(time.UTC, true))
+
+	var runs, overlapRuns atomic.Int32
+	job, err := scheduler.Schedule("* * * * * *", func(_ context.Context) (int, error) {
+		runs.Add(1)
+		return 0, nil
+	})
+	assert.IsNil(t, err)
+	next := job.Next()
+	assert.Equal(t, true, next.After(time.Now()))
+	assert.Equal(t, 0, next.Nanosecond())
+
+	release := make(chan struct{})
+	_, err = scheduler.Schedule("* * * * * *", func(_ context.Context) (int, error) {
+		overlapRuns.Add(1)
+		<-release
+		return 0, nil
+	})
+	assert.IsNil(t, err)
+	assert.Equal(t, 2, len(scheduler.Jobs()))
+
+	time.Sleep(2100 * time.Millisecond)
+	assert.Equal(t, true, runs.Load() >= 2)
+	// the blocked job is not run again while in progress
+	assert.Equal(t, int32(1), overlapRuns.Load())
+	assert.Equal(t, true, job.Next().After(next))
+
+	job.Stop()
+	assert.Equal(t, true, job.Next().IsZero())
+	assert.Equal(t, 1, len(scheduler.Jobs()))
+
+	scheduler.Stop()
+	close(release)
+	assert.Equal(t, 0, len(scheduler.Jobs()))
+	_, err = scheduler.Schedule("@hourly", func(_ context.Context) (int, error) {
+		return 0, nil
+	})
+	assert.ErrorIs(t, err, async.ErrSchedulerStopped)
+}
@@ -1,11 +1,12 @@
 package async
 
 import (
+	"container/list"
 	"context"
 	"errors"
-	"fmt"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
 // ExecutorStatus represents the status of an [ExecutorService].
@@ -18,20 +19,57 @@ const (
 )
 
 var (
-	ErrExecutorQueueFull = errors.New("async: executor queue is full")
-	ErrExecutorShutDown  = errors.New("async: executor is shut down")
+	ErrExecutorQueueFull     = errors.New("async: executor queue is full")
+	ErrExecutorShutDown      = errors.New("async: executor is shut down")
+	ErrExecutorTaskDiscarded = errors.New("async: executor task is discarded")
 )
 
+// RejectionPolicy defines how an [Executor] handles a task submitted when
+// its queue is full.
+type RejectionPolicy uint32
+
+const (
+	// RejectionPolicyAbort rejects the task, returning [ErrExecutorQueueFull]
+	// from Submit.
+	RejectionPolicyAbort RejectionPolicy = iota
+	// RejectionPolicyCallerRuns executes the task synchronously on the
+	// submitting goroutine.
+	RejectionPolicyCallerRuns
+	// RejectionPolicyDiscardOldest fails the oldest queued task with
+	// [ErrExecutorTaskDiscarded] and queues the submitted task instead.
+	RejectionPolicyDiscardOldest
+	// RejectionPolicyDiscard fails the submitted task with
+	// [ErrExecutorTaskDiscarded], without returning an error from Submit.
+	RejectionPolicyDiscard
+)
+
+// RejectedTask represents a task submitted to an [Executor] with a full
+// queue. The Future returned by Submit is completed only once the task
+// is run or discarded.
+type RejectedTask interface {
+	// Run executes the task on the calling goroutine.
+	Run()
+	// Discard fails the task with the given error.
+	Discard(error)
+}
+
+// RejectionHandler is a custom handler of the tasks submitted to an
+// [Executor] with a full queue. A non-nil error returned by the handler
+// is returned from Submit.
+type RejectionHandler func(RejectedTask) error
+
 // ExecutorService is an interface that defines a task executor.
 type ExecutorService[T any] interface {
 	// Submit submits a function to the executor service.
 	// The function will be executed asynchronously and the result will be
 	// available via the returned future.
+	// Cancelling the returned future cancels the context passed to the
+	// function, or discards the task if it has not started yet.
 	Submit(func(context.Context) (T, error)) (Future[T], error)
 
-	// Shutdown shuts down the executor service.
-	// Once the executor service is shut down, no new tasks can be submitted
-	// and any pending tasks will be cancelled.
+	// Shutdown initiates a graceful shutdown of the executor service.
+	// No new tasks can be submitted, while the previously submitted tasks
+	// are still executed.
 	Shutdown() error
 
 	// Status returns the current status of the executor service.
@@ -40,8 +78,31 @@ type ExecutorService[T any] interface {
 
 // ExecutorConfig represents the Executor configuration.
 type ExecutorConfig struct {
+	// WorkerPoolSize is the number of workers, used as the core pool size
+	// if CorePoolSize is not set.
 	WorkerPoolSize int
 	QueueSize      int
+	// CorePoolSize is the number of workers kept running even when idle.
+	CorePoolSize int
+	// MaxPoolSize is the maximum number of workers. Workers above the core
+	// pool size are started when the queue is full and retired once idle
+	// for the KeepAlive duration. If less than the core pool size, the pool
+	// size is fixed.
+	MaxPoolSize int
+	// KeepAlive is the duration a worker above the core pool size can stay
+	// idle before it is retired.
+	KeepAlive time.Duration
+	// PanicHandler is invoked with panics recovered from the submitted
+	// tasks. If nil, the global handler set by [SetPanicHandler] is used.
+	PanicHandler PanicHandler
+	// RejectionPolicy defines how tasks submitted when the queue is full
+	// are handled. It does not apply to SubmitContext, which blocks instead.
+	RejectionPolicy RejectionPolicy
+	// RejectionHandler, if set, handles the tasks submitted when the queue
+	// is full instead of the RejectionPolicy.
+	RejectionHandler RejectionHandler
+	// Metrics, if set, receives the task lifecycle events.
+	Metrics ExecutorMetrics
 }
 
 // NewExecutorConfig returns a new [ExecutorConfig].
@@ -53,43 +114,162 @@ func NewExecutorConfig(workerPoolSize, queueSize int) *ExecutorConfig {
 	}
 }
 
+// NewElasticExecutorConfig returns a new [ExecutorConfig] for a worker pool
+// growing from corePoolSize up to maxPoolSize workers when the queue is
+// full, and shrinking back after the keepAlive idle duration.
+// corePoolSize must be positive, maxPoolSize not less than corePoolSize and
+// queueSize non-negative.
+func NewElasticExecutorConfig(corePoolSize, maxPoolSize, queueSize int,
+	keepAlive time.Duration,
+) *ExecutorConfig {
+	return &ExecutorConfig{
+		QueueSize:    queueSize,
+		CorePoolSize: corePoolSize,
+		MaxPoolSize:  maxPoolSize,
+		KeepAlive:    keepAlive,
+	}
+}
+
 // Executor implements the [ExecutorService] interface.
 type Executor[T any] struct {
-	cancel context.CancelFunc
-	queue  chan executorJob[T]
-	mtx    sync.RWMutex
-	status atomic.Uint32
+	ctx              context.Context
+	cancel           context.CancelFunc
+	queue            *jobQueue[T]
+	panicHandler     PanicHandler
+	rejectionPolicy  RejectionPolicy
+	rejectionHandler RejectionHandler
+	mtx              sync.RWMutex
+	status           atomic.Uint32
+	// shutdown is closed to stop intake and drain the queue gracefully
+	shutdown     chan struct{}
+	shutdownOnce sync.Once
+	// terminated is closed once the executor is shut down
+	terminated chan struct{}
+	// pool holds the state of the worker pool
+	pool workerPool
+	// stats holds the task counters
+	stats executorStats
 }
 
 var _ ExecutorService[any] = (*Executor[any])(nil)
 
 type executorJob[T any] struct {
-	promise Promise[T]
-	task    func(context.Context) (T, error)
+	ctx       context.Context
+	cancel    context.CancelCauseFunc
+	promise   Promise[T]
+	task      func(context.Context) (T, error)
+	submitted time.Time
+	// priority is the priority of the job in a priority queue.
+	priority int
+	// the position of the job in the queue, guarded by the queue mutex:
+	// elem is the element in a FIFO queue, nil if the job is not queued;
+	// key, seq and index order the job in a priority queue, with the index
+	// of -1 if the job is not queued.
+	elem  *list.Element
+	key   int64
+	seq   uint64
+	index int
 }
 
-// run executes the task, handling possible panics.
-func (job *executorJob[T]) run(ctx context.Context) (result T, err error) {
+// newExecutorJob returns a new executorJob with a per-task context derived
+// from ctx. Cancelling the job's future cancels the task context.
+func newExecutorJob[T any](ctx context.Context,
+	task func(context.Context) (T, error),
+) executorJob[T] {
+	ctx, cancel := context.WithCancelCause(ctx)
+	promise := NewPromise[T]()
+	promise.Future().(*futureImpl[T]).cancelFunc = func() {
+		cancel(ErrCancelled)
+	}
+	return executorJob[T]{
+		ctx:       ctx,
+		cancel:    cancel,
+		promise:   promise,
+		task:      task,
+		submitted: time.Now(),
+		index:     -1,
+	}
+}
+
+// run executes the task, converting a possible panic into a [*PanicError].
+func (job *executorJob[T]) run(panicHandler PanicHandler) (result T, err error) {
 	defer func() {
 		if r := recover(); r != nil {
-			err = fmt.Errorf("recovered: %v", r)
+			err = newPanicError(r, panicHandler)
 		}
 	}()
-	return job.task(ctx)
+	return job.task(job.ctx)
+}
+
+// reject fails the job's promise with the given error.
+// Returns false if the promise has already been completed.
+func (job *executorJob[T]) reject(err error) bool {
+	job.cancel(err)
+	return job.promise.TryFailure(err)
+}
+
+// rejectedTask implements the RejectedTask interface.
+type rejectedTask[T any] struct {
+	job      *executorJob[T]
+	executor *Executor[T]
+}
+
+// Run executes the task on the calling goroutine.
+func (r *rejectedTask[T]) Run() {
+	r.executor.execute(r.job)
+}
+
+// Discard fails the task with the given error.
+func (r *rejectedTask[T]) Discard(err error) {
+	r.executor.rejectJob(r.job, err)
 }
 
 // NewExecutor returns a new [Executor].
+// It panics if the configured pool sizes are invalid.
 func NewExecutor[T any](ctx context.Context, config *ExecutorConfig) *Executor[T] {
+	return newExecutor(ctx, config, &fifoOrder[T]{})
+}
+
+// newExecutor returns a new [Executor], executing the queued tasks in the
+// given order.
+func newExecutor[T any](ctx context.Context, config *ExecutorConfig,
+	order jobOrder[T],
+) *Executor[T] {
+	corePoolSize := config.CorePoolSize
+	if corePoolSize == 0 {
+		corePoolSize = config.WorkerPoolSize
+	}
+	if err := validatePoolSize(corePoolSize, max(corePoolSize, config.MaxPoolSize)); err != nil {
+		panic(err)
+	}
+
 	ctx, cancel := context.WithCancel(ctx)
 	executor := &Executor[T]{
-		cancel: cancel,
-		queue:  make(chan executorJob[T], config.QueueSize),
+		ctx:              ctx,
+		cancel:           cancel,
+		queue:            newJobQueue(config.QueueSize, order),
+		panicHandler:     config.PanicHandler,
+		rejectionPolicy:  config.RejectionPolicy,
+		rejectionHandler: config.RejectionHandler,
+		shutdown:         make(chan struct{}),
+		terminated:       make(chan struct{}),
+		stats: executorStats{
+			metrics: config.Metrics,
+		},
+		pool: workerPool{
+			corePoolSize: corePoolSize,
+			maxPoolSize:  max(corePoolSize, config.MaxPoolSize),
+			keepAlive:    config.KeepAlive,
+			retire:       make(chan struct{}),
+		},
 	}
 	// set the executor status to running explicitly
 	executor.status.Store(uint32(ExecutorStatusRunning))
 
-	// init the workers pool
-	go executor.startWorkers(ctx, config.WorkerPoolSize)
+	// init the core workers
+	for range corePoolSize {
+		executor.addWorker(nil)
+	}
 
 	// set status to terminating when ctx is done
 	go executor.monitorCtx(ctx)
@@ -103,75 +283,238 @@ func (e *Executor[T]) monitorCtx(ctx context.Context) {
 		uint32(ExecutorStatusTerminating))
 }
 
-func (e *Executor[T]) startWorkers(ctx context.Context, poolSize int) {
-	var wg sync.WaitGroup
-	for range poolSize {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-		loop:
-			// check the status to break the loop even if the queue is not empty
-			for ExecutorStatus(e.status.Load()) == ExecutorStatusRunning {
-				select {
-				case job := <-e.queue:
-					result, err := job.run(ctx)
-					if err != nil {
-						job.promise.Failure(err)
-					} else {
-						job.promise.Success(result)
-					}
-				case <-ctx.Done():
-					break loop
-				}
-			}
-		}()
-	}
-
-	// wait for all workers to exit
-	wg.Wait()
-	// mark the executor as terminating
-	e.status.Store(uint32(ExecutorStatusTerminating))
-
-	// avoid submissions while draining the queue
-	e.mtx.Lock()
-	defer e.mtx.Unlock()
-
-	// close the queue and cancel all pending tasks
-	close(e.queue)
-	for job := range e.queue {
-		job.promise.Failure(ErrExecutorShutDown)
-	}
-	// mark the executor as shut down
-	e.status.Store(uint32(ExecutorStatusShutDown))
-}
-
 // Submit submits a function to the executor.
 // The function will be executed asynchronously and the result will be
 // available via the returned future.
+// If the queue is full, the task is handled according to the configured
+// [RejectionPolicy] or [RejectionHandler].
 func (e *Executor[T]) Submit(f func(context.Context) (T, error)) (Future[T], error) {
+	return e.submit(0, f, false)
+}
+
+// submit submits a function to the executor with the given priority, which
+// only applies to a priority queue. If abort is set, a task which cannot be
+// queued is rejected with [ErrExecutorQueueFull] regardless of the
+// configured rejection policy, so that the internal submissions neither
+// block nor have their tasks discarded.
+func (e *Executor[T]) submit(priority int, f func(context.Context) (T, error),
+	abort bool,
+) (Future[T], error) {
+	e.stats.taskSubmitted()
 	e.mtx.RLock()
-	defer e.mtx.RUnlock()
+	if ExecutorStatus(e.status.Load()) != ExecutorStatusRunning {
+		e.mtx.RUnlock()
+		e.stats.taskRejected(ErrExecutorShutDown)
+		return nil, ErrExecutorShutDown
+	}
+	job := e.newJob(priority, f)
+	queued := e.enqueue(job, abort)
+	// release the lock before running the rejection handler, which may
+	// execute the task on the calling goroutine
+	e.mtx.RUnlock()
 
-	if ExecutorStatus(e.status.Load()) == ExecutorStatusRunning {
-		promise := NewPromise[T]()
+	switch {
+	case queued:
+		return job.promise.Future(), nil
+	case abort:
+		e.rejectJob(job, ErrExecutorQueueFull)
+		return nil, ErrExecutorQueueFull
+	default:
+		return e.reject(job)
+	}
+}
+
+// newJob returns a new job with the given priority, which is removed from
+// the queue when its future is cancelled.
+func (e *Executor[T]) newJob(priority int,
+	f func(context.Context) (T, error),
+) *executorJob[T] {
+	job := newExecutorJob(e.ctx, f)
+	job.priority = priority
+	future := job.promise.Future().(*futureImpl[T])
+	cancel := future.cancelFunc
+	future.cancelFunc = func() {
+		cancel()
+		e.queue.remove(&job)
+	}
+	return &job
+}
+
+// enqueue queues the job without blocking and reports whether it succeeded.
+// If the queue is full, a new worker is started for the job if the pool can
+// grow. Under the DiscardOldest policy, unless abort is set, the oldest
+// queued jobs are discarded to make room for the job.
+func (e *Executor[T]) enqueue(job *executorJob[T], abort bool) bool {
+	for {
+		if e.offer(job) {
+			return true
+		}
+		if abort || e.rejectionHandler != nil ||
+			e.rejectionPolicy != RejectionPolicyDiscardOldest {
+			return false
+		}
+		oldest, ok := e.queue.poll()
+		if !ok {
+			// there is nothing to discard, e.g. the queue capacity is zero
+			return false
+		}
+		e.rejectJob(oldest, ErrExecutorTaskDiscarded)
+	}
+}
+
+// reject handles a job which could not be queued.
+func (e *Executor[T]) reject(job *executorJob[T]) (Future[T], error) {
+	if e.rejectionHandler != nil {
+		if err := e.rejectionHandler(&rejectedTask[T]{job, e}); err != nil {
+			e.rejectJob(job, err)
+			return nil, err
+		}
+		return job.promise.Future(), nil
+	}
+	switch e.rejectionPolicy {
+	case RejectionPolicyCallerRuns:
+		e.execute(job)
+		return job.promise.Future(), nil
+	case RejectionPolicyDiscard:
+		e.rejectJob(job, ErrExecutorTaskDiscarded)
+		return job.promise.Future(), nil
+	default:
+		e.rejectJob(job, ErrExecutorQueueFull)
+		return nil, ErrExecutorQueueFull
+	}
+}
+
+// rejectJob fails the job with the given error, recording the rejection
+// unless the job has already been completed.
+func (e *Executor[T]) rejectJob(job *executorJob[T], err error) {
+	if job.reject(err) {
+		e.stats.taskRejected(err)
+	}
+}
+
+// execute runs the job's task and completes its promise with the result.
+// A job whose future has been cancelled while queued is discarded.
+func (e *Executor[T]) execute(job *executorJob[T]) {
+	defer job.cancel(nil)
+	if context.Cause(job.ctx) == ErrCancelled {
+		return
+	}
+	start := time.Now()
+	e.stats.taskStarted(start.Sub(job.submitted))
+	value, err := job.run(e.panicHandler)
+	// record the stats before completing the promise, to make them visible
+	// to the waiters of the future
+	e.stats.taskFinished(time.Since(start), err)
+	job.promise.Complete(value, err)
+}
+
+// SubmitContext submits a function to the executor, blocking until there
+// is space in the queue, the context is done or the executor is shut down.
+// The context only bounds the submission; it is not passed to the function.
+// If the context is done before the function is queued, its error is
+// returned.
+func (e *Executor[T]) SubmitContext(ctx context.Context,
+	f func(context.Context) (T, error),
+) (Future[T], error) {
+	return e.submitContext(ctx, 0, f)
+}
+
+// submitContext submits a function to the executor with the given priority,
+// blocking until there is space in the queue.
+func (e *Executor[T]) submitContext(ctx context.Context, priority int,
+	f func(context.Context) (T, error),
+) (Future[T], error) {
+	e.stats.taskSubmitted()
+	job := e.newJob(priority, f)
+	for {
+		space := e.queue.awaitSpace()
+		// hold the lock only while offering the job, so that a blocked
+		// submission does not delay the shutdown
+		e.mtx.RLock()
+		if ExecutorStatus(e.status.Load()) != ExecutorStatusRunning {
+			e.mtx.RUnlock()
+			e.rejectJob(job, ErrExecutorShutDown)
+			return nil, ErrExecutorShutDown
+		}
+		if err := ctx.Err(); err != nil {
+			e.mtx.RUnlock()
+			e.rejectJob(job, err)
+			return nil, err
+		}
+		queued := e.offer(job)
+		e.mtx.RUnlock()
+		if queued {
+			return job.promise.Future(), nil
+		}
+
+		// retry once there may be space, or report the reason to stop
 		select {
-		case e.queue <- executorJob[T]{promise, f}:
-			return promise.Future(), nil
-		default:
-			return nil, ErrExecutorQueueFull
+		case <-space:
+		case <-ctx.Done():
+		case <-e.ctx.Done():
+		case <-e.shutdown:
 		}
 	}
-	return nil, ErrExecutorShutDown
 }
 
-// Shutdown shuts down the executor.
-// Once the executor service is shut down, no new tasks can be submitted
-// and any pending tasks will be cancelled.
+// Shutdown initiates a graceful shutdown of the executor.
+// No new tasks can be submitted, while the running and queued tasks are
+// executed before the executor is shut down. Use [Executor.AwaitTermination]
+// to wait for the completion of the shutdown.
 func (e *Executor[T]) Shutdown() error {
-	e.cancel()
+	e.shutdownOnce.Do(func() {
+		_ = e.status.CompareAndSwap(uint32(ExecutorStatusRunning),
+			uint32(ExecutorStatusTerminating))
+		close(e.shutdown)
+	})
 	return nil
 }
 
+// ShutdownNow shuts down the executor immediately.
+// No new tasks can be submitted, the contexts of the running tasks are
+// cancelled, and the queued tasks are failed with [ErrExecutorShutDown].
+// Returns the queued tasks which have not started, on a best-effort basis,
+// so that they can be resubmitted elsewhere.
+func (e *Executor[T]) ShutdownNow() []func(context.Context) (T, error) {
+	_ = e.status.CompareAndSwap(uint32(ExecutorStatusRunning),
+		uint32(ExecutorStatusTerminating))
+	// drain the queue before cancelling the context, which stops the
+	// workers, so that none of the queued jobs is taken by them
+	tasks := e.drain()
+	e.cancel()
+	return tasks
+}
+
+// drain fails the queued jobs with [ErrExecutorShutDown] once the in-flight
+// submissions have completed. Returns the tasks of the drained jobs, except
+// for the jobs cancelled by the caller.
+func (e *Executor[T]) drain() []func(context.Context) (T, error) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	var tasks []func(context.Context) (T, error)
+	for {
+		job, ok := e.queue.poll()
+		if !ok {
+			return tasks
+		}
+		if context.Cause(job.ctx) != ErrCancelled {
+			tasks = append(tasks, job.task)
+		}
+		e.rejectJob(job, ErrExecutorShutDown)
+	}
+}
+
+// AwaitTermination blocks until the executor is shut down or the context
+// is done, in which case the context error is returned.
+func (e *Executor[T]) AwaitTermination(ctx context.Context) error {
+	select {
+	case <-e.terminated:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // Status returns the current status of the executor.
 func (e *Executor[T]) Status() ExecutorStatus {
 	return ExecutorStatus(e.status.Load())
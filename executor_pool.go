@@ -0,0 +1,228 @@
+package async
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// workerPool holds the state of the Executor worker pool.
+type workerPool struct {
+	mtx          sync.Mutex
+	corePoolSize int
+	maxPoolSize  int
+	keepAlive    time.Duration
+	// workers is the number of running workers; guarded by mtx.
+	workers int
+	// terminating is set once the last worker has exited; guarded by mtx.
+	terminating bool
+	// idle is the number of workers waiting for a job.
+	idle atomic.Int32
+	// retire is used to signal idle workers to exit after a resize.
+	retire chan struct{}
+}
+
+// validatePoolSize returns an error if the pool sizes are invalid.
+func validatePoolSize(corePoolSize, maxPoolSize int) error {
+	if corePoolSize <= 0 {
+		return fmt.Errorf("nonpositive core pool size: %d", corePoolSize)
+	}
+	if maxPoolSize < corePoolSize {
+		return fmt.Errorf("max pool size %d is less than core pool size %d",
+			maxPoolSize, corePoolSize)
+	}
+	return nil
+}
+
+// Resize changes the core and the maximum number of workers at runtime.
+// Missing core workers are started immediately, while the excess workers
+// are retired once idle.
+// corePoolSize must be positive and maxPoolSize not less than corePoolSize.
+func (e *Executor[T]) Resize(corePoolSize, maxPoolSize int) error {
+	if err := validatePoolSize(corePoolSize, maxPoolSize); err != nil {
+		return err
+	}
+
+	e.mtx.RLock()
+	defer e.mtx.RUnlock()
+	if ExecutorStatus(e.status.Load()) != ExecutorStatusRunning {
+		return ErrExecutorShutDown
+	}
+
+	e.pool.mtx.Lock()
+	e.pool.corePoolSize = corePoolSize
+	e.pool.maxPoolSize = maxPoolSize
+	missing := corePoolSize - e.pool.workers
+	excess := e.pool.workers - corePoolSize
+	e.pool.mtx.Unlock()
+
+	for range missing {
+		e.addWorker(nil)
+	}
+	// wake up the idle excess workers
+	for range excess {
+		select {
+		case e.pool.retire <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// offer queues the job without blocking, starting a new worker for it if
+// the queue is full and the pool can grow.
+// Reports whether the job was accepted.
+func (e *Executor[T]) offer(job *executorJob[T]) bool {
+	if e.queue.offer(job, int(e.pool.idle.Load())) {
+		return true
+	}
+	return e.addWorker(job)
+}
+
+// addWorker starts a new worker, executing the given job first if not nil.
+// Reports whether the worker was started, i.e. the pool has not reached
+// its maximum size.
+func (e *Executor[T]) addWorker(job *executorJob[T]) bool {
+	e.pool.mtx.Lock()
+	defer e.pool.mtx.Unlock()
+	if e.pool.terminating || e.pool.workers >= e.pool.maxPoolSize {
+		return false
+	}
+	e.pool.workers++
+	go e.work(job)
+	return true
+}
+
+// work executes the given job, if not nil, and then the queued jobs until
+// the executor is shut down or the worker is retired.
+func (e *Executor[T]) work(job *executorJob[T]) {
+	if job != nil {
+		e.execute(job)
+	}
+	if e.serve() {
+		return
+	}
+
+	e.pool.mtx.Lock()
+	e.pool.workers--
+	last := e.pool.workers == 0
+	e.pool.terminating = last
+	e.pool.mtx.Unlock()
+
+	if last {
+		e.terminate()
+	}
+}
+
+// serve executes the queued jobs until the context is done, or the queue
+// is drained after a graceful shutdown.
+// Returns true if the worker has been retired from the pool.
+func (e *Executor[T]) serve() bool {
+	timer := time.NewTimer(time.Hour)
+	timer.Stop()
+	defer timer.Stop()
+	for {
+		// stop taking jobs once the executor is shut down immediately
+		if e.ctx.Err() != nil {
+			return false
+		}
+		if job, ok := e.queue.poll(); ok {
+			e.execute(job)
+			continue
+		}
+
+		// only the workers above the core pool size time out
+		var timeout <-chan time.Time
+		if keepAlive, ok := e.excessWorker(); ok {
+			timer.Reset(keepAlive)
+			timeout = timer.C
+		}
+
+		e.pool.idle.Add(1)
+		// an idle worker can accept a job even if the queue is full
+		e.queue.notifySpace()
+		select {
+		case <-e.queue.ready:
+			e.pool.idle.Add(-1)
+			timer.Stop()
+		case <-e.ctx.Done():
+			e.pool.idle.Add(-1)
+			return false
+		case <-e.shutdown:
+			e.pool.idle.Add(-1)
+			e.awaitSubmissions()
+			for e.ctx.Err() == nil {
+				job, ok := e.queue.poll()
+				if !ok {
+					break
+				}
+				e.execute(job)
+			}
+			return false
+		case <-timeout:
+			e.pool.idle.Add(-1)
+			if e.retireWorker() {
+				return true
+			}
+		case <-e.pool.retire:
+			e.pool.idle.Add(-1)
+			timer.Stop()
+			if e.retireWorker() {
+				return true
+			}
+		}
+	}
+}
+
+// excessWorker reports whether the pool runs more workers than the core
+// pool size, returning the keep-alive duration.
+func (e *Executor[T]) excessWorker() (time.Duration, bool) {
+	e.pool.mtx.Lock()
+	defer e.pool.mtx.Unlock()
+	return e.pool.keepAlive, e.pool.workers > e.pool.corePoolSize
+}
+
+// retireWorker removes a worker from the pool if it runs more workers than
+// the core pool size. Reports whether the worker was removed.
+func (e *Executor[T]) retireWorker() bool {
+	e.pool.mtx.Lock()
+	defer e.pool.mtx.Unlock()
+	if e.pool.workers <= e.pool.corePoolSize {
+		return false
+	}
+	e.pool.workers--
+	return true
+}
+
+// awaitSubmissions waits for the in-flight submissions, which hold the read
+// lock, to complete.
+func (e *Executor[T]) awaitSubmissions() {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+}
+
+// terminate completes the shutdown once all the workers have exited,
+// failing the jobs left in the queue.
+func (e *Executor[T]) terminate() {
+	// mark the executor as terminating
+	e.status.Store(uint32(ExecutorStatusTerminating))
+
+	// avoid submissions while draining the queue
+	e.mtx.Lock()
+	// cancel all pending tasks
+	for {
+		job, ok := e.queue.poll()
+		if !ok {
+			break
+		}
+		e.rejectJob(job, ErrExecutorShutDown)
+	}
+	// mark the executor as shut down
+	e.status.Store(uint32(ExecutorStatusShutDown))
+	e.mtx.Unlock()
+
+	// release the context resources after a graceful shutdown
+	e.cancel()
+	close(e.terminated)
+}
@@ -0,0 +1,260 @@
+package async
+
+import (
+	"container/heap"
+	"container/list"
+	"math"
+	"sync"
+	"time"
+)
+
+// jobOrder defines the order in which the queued jobs are executed.
+// It is not safe for concurrent use; the jobQueue mutex must be held.
+type jobOrder[T any] interface {
+	// push adds the job to the order.
+	push(job *executorJob[T])
+	// pop removes and returns the next job, or nil if there is none.
+	pop() *executorJob[T]
+	// remove removes the job if it is present, reporting whether it was.
+	remove(job *executorJob[T]) bool
+	// len returns the number of jobs.
+	len() int
+}
+
+// jobQueue is the bounded queue of the Executor jobs, which allows removing
+// the jobs cancelled while queued to release their capacity.
+type jobQueue[T any] struct {
+	mtx      sync.Mutex
+	jobs     jobOrder[T]
+	capacity int
+	// ready is signalled when a job is queued, to wake up an idle worker.
+	ready chan struct{}
+	// space is closed when the queue may accept a job, to wake up the
+	// blocked submissions; created on demand and guarded by mtx.
+	space chan struct{}
+}
+
+// newJobQueue returns a new jobQueue with the given capacity, ordering the
+// jobs by the given order.
+func newJobQueue[T any](capacity int, order jobOrder[T]) *jobQueue[T] {
+	return &jobQueue[T]{
+		jobs:     order,
+		capacity: capacity,
+		ready:    make(chan struct{}, 1),
+	}
+}
+
+// offer queues the job unless the queue is full. The jobs waiting to be
+// picked up by the idle workers do not count against the capacity, so that
+// a queue of zero capacity hands off jobs to the idle workers only.
+// Reports whether the job was queued.
+func (q *jobQueue[T]) offer(job *executorJob[T], idle int) bool {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	if q.jobs.len() >= q.capacity+idle {
+		return false
+	}
+	q.jobs.push(job)
+	q.signal()
+	return true
+}
+
+// poll removes and returns the next job, if any.
+func (q *jobQueue[T]) poll() (*executorJob[T], bool) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	job := q.jobs.pop()
+	if job == nil {
+		return nil, false
+	}
+	q.releaseSpace()
+	// pass the signal on to another idle worker
+	if q.jobs.len() > 0 {
+		q.signal()
+	}
+	return job, true
+}
+
+// remove removes the job from the queue if it is still queued, waking up
+// the blocked submissions.
+func (q *jobQueue[T]) remove(job *executorJob[T]) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	if q.jobs.remove(job) {
+		q.releaseSpace()
+	}
+}
+
+// signal wakes up an idle worker without blocking. The mutex must be held.
+func (q *jobQueue[T]) signal() {
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+}
+
+// awaitSpace returns a channel which is closed once the queue may accept
+// a job. It must be obtained before offering the job, to not miss the
+// release of space.
+func (q *jobQueue[T]) awaitSpace() <-chan struct{} {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	if q.space == nil {
+		q.space = make(chan struct{})
+	}
+	return q.space
+}
+
+// notifySpace wakes up the blocked submissions, e.g. when a worker becomes
+// idle.
+func (q *jobQueue[T]) notifySpace() {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	q.releaseSpace()
+}
+
+// releaseSpace closes the space channel, if any. The mutex must be held.
+func (q *jobQueue[T]) releaseSpace() {
+	if q.space != nil {
+		close(q.space)
+		q.space = nil
+	}
+}
+
+// len returns the number of queued jobs.
+func (q *jobQueue[T]) len() int {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	return q.jobs.len()
+}
+
+// fifoOrder is the jobOrder executing the jobs in the order of submission.
+type fifoOrder[T any] struct {
+	jobs list.List
+}
+
+var _ jobOrder[any] = (*fifoOrder[any])(nil)
+
+func (o *fifoOrder[T]) push(job *executorJob[T]) {
+	job.elem = o.jobs.PushBack(job)
+}
+
+func (o *fifoOrder[T]) pop() *executorJob[T] {
+	front := o.jobs.Front()
+	if front == nil {
+		return nil
+	}
+	job := o.jobs.Remove(front).(*executorJob[T])
+	job.elem = nil
+	return job
+}
+
+func (o *fifoOrder[T]) remove(job *executorJob[T]) bool {
+	if job.elem == nil {
+		return false
+	}
+	o.jobs.Remove(job.elem)
+	job.elem = nil
+	return true
+}
+
+func (o *fifoOrder[T]) len() int {
+	return o.jobs.Len()
+}
+
+// priorityOrder is the jobOrder executing the jobs in the order of their
+// priority, and then in the order of submission.
+type priorityOrder[T any] struct {
+	jobs  priorityHeap[T]
+	aging time.Duration
+	start time.Time
+	seq   uint64
+}
+
+var _ jobOrder[any] = (*priorityOrder[any])(nil)
+
+// newPriorityOrder returns a new priorityOrder, in which a queued job gains
+// one priority level per aging interval. Zero disables aging.
+func newPriorityOrder[T any](aging time.Duration) *priorityOrder[T] {
+	return &priorityOrder[T]{
+		aging: aging,
+		start: time.Now(),
+	}
+}
+
+func (o *priorityOrder[T]) push(job *executorJob[T]) {
+	job.key = o.key(job.priority)
+	job.seq = o.seq
+	o.seq++
+	heap.Push(&o.jobs, job)
+}
+
+// key returns the heap key of a job with the given priority, queued now.
+// With aging, the key decreases by one priority level per aging interval
+// elapsed since the order was created, so that a job queued earlier gains
+// priority relative to the newer ones without reordering the heap.
+func (o *priorityOrder[T]) key(priority int) int64 {
+	if o.aging <= 0 {
+		return int64(priority)
+	}
+	levels := int64(time.Since(o.start) / o.aging)
+	// saturate instead of overflowing for the lowest priorities
+	if int64(priority) < math.MinInt64+levels {
+		return math.MinInt64
+	}
+	return int64(priority) - levels
+}
+
+func (o *priorityOrder[T]) pop() *executorJob[T] {
+	if len(o.jobs) == 0 {
+		return nil
+	}
+	return heap.Pop(&o.jobs).(*executorJob[T])
+}
+
+func (o *priorityOrder[T]) remove(job *executorJob[T]) bool {
+	if job.index < 0 {
+		return false
+	}
+	heap.Remove(&o.jobs, job.index)
+	return true
+}
+
+func (o *priorityOrder[T]) len() int {
+	return len(o.jobs)
+}
+
+// priorityHeap implements heap.Interface, ordering jobs by key and then
+// by submission order.
+type priorityHeap[T any] []*executorJob[T]
+
+func (h priorityHeap[T]) Len() int { return len(h) }
+
+func (h priorityHeap[T]) Less(i, j int) bool {
+	if h[i].key != h[j].key {
+		return h[i].key > h[j].key
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h priorityHeap[T]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *priorityHeap[T]) Push(x any) {
+	job := x.(*executorJob[T])
+	job.index = len(*h)
+	*h = append(*h, job)
+}
+
+func (h *priorityHeap[T]) Pop() any {
+	old := *h
+	n := len(old)
+	job := old[n-1]
+	old[n-1] = nil
+	job.index = -1
+	*h = old[:n-1]
+	return job
+}
@@ -0,0 +1,165 @@
+package async
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// ExecutorMetrics receives the task lifecycle events of an [Executor],
+// e.g. to bridge them to a metrics system. The methods are invoked
+// synchronously by the submitting or the worker goroutines and must not
+// block.
+type ExecutorMetrics interface {
+	// TaskSubmitted is invoked when a task is submitted to the executor.
+	TaskSubmitted()
+	// TaskStarted is invoked when a task is started, with the time it has
+	// spent in the queue.
+	TaskStarted(queueWait time.Duration)
+	// TaskFinished is invoked when a task is finished, with its run time
+	// and the resulting error, if any.
+	TaskFinished(runTime time.Duration, err error)
+	// TaskRejected is invoked when a task is rejected by the executor,
+	// e.g. with [ErrExecutorQueueFull] or [ErrExecutorShutDown].
+	TaskRejected(err error)
+	// TaskPanicked is invoked when a task panics.
+	TaskPanicked(*PanicError)
+}
+
+// LatencyStats summarizes the observed durations of a task lifecycle phase.
+type LatencyStats struct {
+	Count int64
+	Total time.Duration
+	Max   time.Duration
+}
+
+// Mean returns the mean of the observed durations.
+func (l LatencyStats) Mean() time.Duration {
+	if l.Count == 0 {
+		return 0
+	}
+	return l.Total / time.Duration(l.Count)
+}
+
+// ExecutorStats represents a snapshot of the [Executor] statistics.
+// Tasks cancelled while queued are neither started nor finished.
+type ExecutorStats struct {
+	// QueueDepth is the number of queued tasks.
+	QueueDepth int
+	// PoolSize is the number of running workers.
+	PoolSize int
+	// ActiveWorkers is the number of workers executing a task.
+	ActiveWorkers int
+	// Submitted is the number of tasks submitted to the executor.
+	Submitted uint64
+	// Completed is the number of tasks finished successfully.
+	Completed uint64
+	// Failed is the number of tasks finished with an error, including
+	// the panicked ones.
+	Failed uint64
+	// Rejected is the number of tasks rejected or discarded by the executor.
+	Rejected uint64
+	// Panicked is the number of tasks which panicked.
+	Panicked uint64
+	// QueueWait summarizes the time the started tasks spent in the queue.
+	QueueWait LatencyStats
+	// RunTime summarizes the run time of the finished tasks.
+	RunTime LatencyStats
+}
+
+// executorStats holds the Executor counters.
+type executorStats struct {
+	metrics   ExecutorMetrics
+	submitted atomic.Uint64
+	completed atomic.Uint64
+	failed    atomic.Uint64
+	rejected  atomic.Uint64
+	panicked  atomic.Uint64
+	queueWait latencyStats
+	runTime   latencyStats
+}
+
+// latencyStats accumulates observed durations.
+type latencyStats struct {
+	count atomic.Int64
+	total atomic.Int64
+	max   atomic.Int64
+}
+
+func (l *latencyStats) observe(d time.Duration) {
+	l.count.Add(1)
+	l.total.Add(int64(d))
+	for {
+		current := l.max.Load()
+		if int64(d) <= current || l.max.CompareAndSwap(current, int64(d)) {
+			return
+		}
+	}
+}
+
+func (l *latencyStats) snapshot() LatencyStats {
+	return LatencyStats{
+		Count: l.count.Load(),
+		Total: time.Duration(l.total.Load()),
+		Max:   time.Duration(l.max.Load()),
+	}
+}
+
+func (s *executorStats) taskSubmitted() {
+	s.submitted.Add(1)
+	if s.metrics != nil {
+		s.metrics.TaskSubmitted()
+	}
+}
+
+func (s *executorStats) taskStarted(queueWait time.Duration) {
+	s.queueWait.observe(queueWait)
+	if s.metrics != nil {
+		s.metrics.TaskStarted(queueWait)
+	}
+}
+
+func (s *executorStats) taskFinished(runTime time.Duration, err error) {
+	s.runTime.observe(runTime)
+	if err == nil {
+		s.completed.Add(1)
+	} else {
+		s.failed.Add(1)
+		var panicErr *PanicError
+		if errors.As(err, &panicErr) {
+			s.panicked.Add(1)
+			if s.metrics != nil {
+				s.metrics.TaskPanicked(panicErr)
+			}
+		}
+	}
+	if s.metrics != nil {
+		s.metrics.TaskFinished(runTime, err)
+	}
+}
+
+func (s *executorStats) taskRejected(err error) {
+	s.rejected.Add(1)
+	if s.metrics != nil {
+		s.metrics.TaskRejected(err)
+	}
+}
+
+// Stats returns a snapshot of the executor statistics.
+func (e *Executor[T]) Stats() ExecutorStats {
+	e.pool.mtx.Lock()
+	poolSize := e.pool.workers
+	e.pool.mtx.Unlock()
+	return ExecutorStats{
+		QueueDepth:    e.queue.len(),
+		PoolSize:      poolSize,
+		ActiveWorkers: max(poolSize-int(e.pool.idle.Load()), 0),
+		Submitted:     e.stats.submitted.Load(),
+		Completed:     e.stats.completed.Load(),
+		Failed:        e.stats.failed.Load(),
+		Rejected:      e.stats.rejected.Load(),
+		Panicked:      e.stats.panicked.Load(),
+		QueueWait:     e.stats.queueWait.snapshot(),
+		RunTime:       e.stats.runTime.snapshot(),
+	}
+}
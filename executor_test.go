@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"runtime"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -56,13 +57,62 @@ func TestExecutor(t *testing.T) {
 
 	// validate the executor status
 	assert.Equal(t, executor.Status(), async.ExecutorStatusTerminating)
-	time.Sleep(10 * time.Millisecond)
+	assert.IsNil(t, executor.AwaitTermination(ctx))
 	assert.Equal(t, executor.Status(), async.ExecutorStatusShutDown)
 
-	assert.Equal(t, routines, runtime.NumGoroutine()+4)
+	// the workers and the context monitor have exited
+	time.Sleep(time.Millisecond)
+	assert.Equal(t, routines, runtime.NumGoroutine()+3)
 
-	assertFutureResult(t, 1, future1, future2, future3, future4)
-	assertFutureError(t, async.ErrExecutorShutDown, future5, future6)
+	// the queued jobs are executed on graceful shutdown
+	assertFutureResult(t, 1, future1, future2, future3, future4, future5, future6)
+}
+
+func TestExecutor_ShutdownNow(t *testing.T) {
+	ctx := t.Context()
+	executor := async.NewExecutor[int](ctx, async.NewExecutorConfig(1, 3))
+
+	started := make(chan struct{})
+	cause := make(chan error, 1)
+	job := func(ctx context.Context) (int, error) {
+		close(started)
+		<-ctx.Done()
+		cause <- ctx.Err()
+		return 0, ctx.Err()
+	}
+	queuedJob := func(_ context.Context) (int, error) {
+		return 1, nil
+	}
+
+	future1 := submitJob[int](t, executor, job)
+	<-started
+	future2 := submitJob[int](t, executor, queuedJob)
+	future3 := submitJob[int](t, executor, queuedJob)
+	future4 := submitJob[int](t, executor, queuedJob)
+	future4.Cancel()
+
+	// the await times out while the executor is running
+	timeoutCtx, cancel := context.WithTimeout(ctx, time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, executor.AwaitTermination(timeoutCtx), context.DeadlineExceeded)
+
+	// the cancelled job is not returned
+	tasks := executor.ShutdownNow()
+	assert.Equal(t, 2, len(tasks))
+	result, err := tasks[0](ctx)
+	assert.IsNil(t, err)
+	assert.Equal(t, 1, result)
+
+	assert.IsNil(t, executor.AwaitTermination(ctx))
+	assert.Equal(t, executor.Status(), async.ExecutorStatusShutDown)
+	assert.ErrorIs(t, <-cause, context.Canceled)
+	assertFutureError(t, context.Canceled, future1)
+	assertFutureError(t, async.ErrExecutorShutDown, future2, future3)
+	assertFutureError(t, async.ErrCancelled, future4)
+
+	_, err = executor.Submit(queuedJob)
+	assert.ErrorIs(t, err, async.ErrExecutorShutDown)
+	assert.Equal(t, 0, len(executor.ShutdownNow()))
 }
 
 func TestExecutor_context(t *testing.T) {
@@ -108,6 +158,425 @@ func TestExecutor_jobPanic(t *testing.T) {
 	_ = executor.Shutdown()
 }
 
+func TestExecutor_cancel(t *testing.T) {
+	ctx := t.Context()
+	executor := async.NewExecutor[int](ctx, async.NewExecutorConfig(1, 2))
+
+	started := make(chan struct{})
+	cause := make(chan error, 1)
+	job := func(ctx context.Context) (int, error) {
+		close(started)
+		<-ctx.Done()
+		cause <- context.Cause(ctx)
+		return 1, nil
+	}
+	var queuedRuns atomic.Int32
+	queuedJob := func(_ context.Context) (int, error) {
+		queuedRuns.Add(1)
+		return 2, nil
+	}
+
+	future1 := submitJob[int](t, executor, job)
+	<-started
+	future2 := submitJob[int](t, executor, queuedJob)
+
+	// cancel the queued job first, then the running one
+	assert.Equal(t, true, future2.Cancel())
+	assert.Equal(t, true, future1.Cancel())
+	assert.Equal(t, false, future1.Cancel())
+
+	assert.ErrorIs(t, <-cause, async.ErrCancelled)
+	assertFutureError(t, async.ErrCancelled, future1, future2)
+
+	// the executor keeps serving new jobs
+	future3 := submitJob[int](t, executor, queuedJob)
+	assertFutureResult(t, 2, future3)
+	assert.Equal(t, int32(1), queuedRuns.Load())
+
+	_ = executor.Shutdown()
+}
+
+func TestExecutor_cancelQueued(t *testing.T) {
+	ctx := t.Context()
+	executor := async.NewExecutor[int](ctx, async.NewExecutorConfig(1, 1))
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	job := func(_ context.Context) (int, error) {
+		close(started)
+		<-release
+		return 1, nil
+	}
+	queuedJob := func(_ context.Context) (int, error) {
+		return 2, nil
+	}
+
+	future1 := submitJob[int](t, executor, job)
+	<-started
+	future2 := submitJob[int](t, executor, queuedJob)
+	_, err := executor.Submit(queuedJob)
+	assert.ErrorIs(t, err, async.ErrExecutorQueueFull)
+
+	// the cancelled job releases its queue slot
+	assert.Equal(t, true, future2.Cancel())
+	assert.Equal(t, 0, executor.Stats().QueueDepth)
+	future3 := submitJob[int](t, executor, queuedJob)
+
+	close(release)
+	assertFutureResult(t, 1, future1)
+	assertFutureError(t, async.ErrCancelled, future2)
+	assertFutureResult(t, 2, future3)
+
+	_ = executor.Shutdown()
+}
+
+func TestExecutor_SubmitContext(t *testing.T) {
+	ctx := t.Context()
+	executor := async.NewExecutor[int](ctx, async.NewExecutorConfig(1, 1))
+
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	job := func(_ context.Context) (int, error) {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		<-release
+		return 1, nil
+	}
+
+	future1 := submitJob[int](t, executor, job)
+	<-started
+	future2 := submitJob[int](t, executor, job)
+	_, err := executor.Submit(job)
+	assert.ErrorIs(t, err, async.ErrExecutorQueueFull)
+
+	// the queue is full until the context is done
+	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Millisecond)
+	defer cancel()
+	_, err = executor.SubmitContext(timeoutCtx, job)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// the submission is unblocked once there is space in the queue
+	result := make(chan async.Future[int])
+	go func() {
+		future, err := executor.SubmitContext(ctx, job)
+		if err != nil {
+			future = async.Failed[int](err)
+		}
+		result <- future
+	}()
+	time.Sleep(5 * time.Millisecond)
+	close(release)
+	future3 := <-result
+	assertFutureResult(t, 1, future1, future2, future3)
+
+	_ = executor.Shutdown()
+	time.Sleep(time.Millisecond)
+	_, err = executor.SubmitContext(ctx, job)
+	assert.ErrorIs(t, err, async.ErrExecutorShutDown)
+}
+
+func TestExecutor_SubmitContextShutdown(t *testing.T) {
+	ctx := t.Context()
+	executor := async.NewExecutor[int](ctx, async.NewExecutorConfig(1, 0))
+
+	release := make(chan struct{})
+	job := func(_ context.Context) (int, error) {
+		<-release
+		return 1, nil
+	}
+	future, err := executor.SubmitContext(ctx, job)
+	assert.IsNil(t, err)
+
+	// a blocked submission is released by the shutdown
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		_ = executor.Shutdown()
+	}()
+	_, err = executor.SubmitContext(ctx, job)
+	assert.ErrorIs(t, err, async.ErrExecutorShutDown)
+
+	close(release)
+	assertFutureResult(t, 1, future)
+}
+
+func TestExecutor_RejectionPolicy(t *testing.T) {
+	blockingJob := func(release <-chan struct{}) func(context.Context) (int, error) {
+		return func(_ context.Context) (int, error) {
+			<-release
+			return 1, nil
+		}
+	}
+	job := func(_ context.Context) (int, error) {
+		return 2, nil
+	}
+	// saturate returns an executor with a running job and a full queue
+	saturate := func(t *testing.T, config *async.ExecutorConfig,
+		release <-chan struct{},
+	) (*async.Executor[int], async.Future[int], async.Future[int]) {
+		executor := async.NewExecutor[int](t.Context(), config)
+		running := submitJob[int](t, executor, blockingJob(release))
+		time.Sleep(time.Millisecond)
+		queued := submitJob[int](t, executor, blockingJob(release))
+		return executor, running, queued
+	}
+
+	t.Run("Abort", func(t *testing.T) {
+		release := make(chan struct{})
+		executor, running, queued := saturate(t, async.NewExecutorConfig(1, 1), release)
+		future, err := executor.Submit(job)
+		assert.ErrorIs(t, err, async.ErrExecutorQueueFull)
+		assert.IsNil(t, future)
+		close(release)
+		assertFutureResult(t, 1, running, queued)
+		_ = executor.Shutdown()
+	})
+
+	t.Run("CallerRuns", func(t *testing.T) {
+		config := async.NewExecutorConfig(1, 1)
+		config.RejectionPolicy = async.RejectionPolicyCallerRuns
+		release := make(chan struct{})
+		executor, running, queued := saturate(t, config, release)
+		future, err := executor.Submit(job)
+		assert.IsNil(t, err)
+		// the task has been executed by the submitting goroutine
+		assert.Equal(t, async.FutureStateSucceeded, future.State())
+		assertFutureResult(t, 2, future)
+		close(release)
+		assertFutureResult(t, 1, running, queued)
+		_ = executor.Shutdown()
+	})
+
+	t.Run("DiscardOldest", func(t *testing.T) {
+		config := async.NewExecutorConfig(1, 1)
+		config.RejectionPolicy = async.RejectionPolicyDiscardOldest
+		release := make(chan struct{})
+		executor, running, queued := saturate(t, config, release)
+		future, err := executor.Submit(job)
+		assert.IsNil(t, err)
+		assertFutureError(t, async.ErrExecutorTaskDiscarded, queued)
+		close(release)
+		assertFutureResult(t, 1, running)
+		assertFutureResult(t, 2, future)
+		_ = executor.Shutdown()
+	})
+
+	t.Run("Discard", func(t *testing.T) {
+		config := async.NewExecutorConfig(1, 1)
+		config.RejectionPolicy = async.RejectionPolicyDiscard
+		release := make(chan struct{})
+		executor, running, queued := saturate(t, config, release)
+		future, err := executor.Submit(job)
+		assert.IsNil(t, err)
+		assertFutureError(t, async.ErrExecutorTaskDiscarded, future)
+		close(release)
+		assertFutureResult(t, 1, running, queued)
+		_ = executor.Shutdown()
+	})
+
+	t.Run("Handler", func(t *testing.T) {
+		errRejected := errors.New("rejected")
+		var rejected atomic.Int32
+		config := async.NewExecutorConfig(1, 1)
+		config.RejectionHandler = func(task async.RejectedTask) error {
+			if rejected.Add(1) == 1 {
+				task.Run()
+				return nil
+			}
+			task.Discard(errRejected)
+			return errRejected
+		}
+		release := make(chan struct{})
+		executor, running, queued := saturate(t, config, release)
+		future, err := executor.Submit(job)
+		assert.IsNil(t, err)
+		assertFutureResult(t, 2, future)
+		future, err = executor.Submit(job)
+		assert.ErrorIs(t, err, errRejected)
+		assert.IsNil(t, future)
+		close(release)
+		assertFutureResult(t, 1, running, queued)
+		_ = executor.Shutdown()
+	})
+}
+
+func TestExecutor_ElasticPool(t *testing.T) {
+	ctx := t.Context()
+	executor := async.NewExecutor[int](ctx,
+		async.NewElasticExecutorConfig(1, 3, 1, 10*time.Millisecond))
+
+	var running, maxRunning atomic.Int32
+	release := make(chan struct{})
+	job := func(_ context.Context) (int, error) {
+		n := running.Add(1)
+		for {
+			m := maxRunning.Load()
+			if n <= m || maxRunning.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		<-release
+		running.Add(-1)
+		return 1, nil
+	}
+
+	routines := runtime.NumGoroutine()
+	futures := make([]async.Future[int], 4)
+	for i := range futures {
+		futures[i] = submitJob[int](t, executor, job)
+		time.Sleep(time.Millisecond)
+		if i == 1 {
+			// the pool does not grow until the queue is full
+			assert.Equal(t, int32(1), running.Load())
+		}
+	}
+	// the pool has grown to the max size and the queue is full
+	_, err := executor.Submit(job)
+	assert.ErrorIs(t, err, async.ErrExecutorQueueFull)
+	assert.Equal(t, int32(3), running.Load())
+
+	close(release)
+	assertFutureResult(t, 1, futures...)
+	assert.Equal(t, int32(3), maxRunning.Load())
+
+	// the idle workers above the core pool size are retired
+	awaitGoroutines(t, routines, 100*time.Millisecond)
+
+	_ = executor.Shutdown()
+	assert.IsNil(t, executor.AwaitTermination(ctx))
+}
+
+func TestExecutor_Resize(t *testing.T) {
+	ctx := t.Context()
+	executor := async.NewExecutor[int](ctx, async.NewExecutorConfig(1, 0))
+
+	var running atomic.Int32
+	release := make(chan struct{})
+	job := func(_ context.Context) (int, error) {
+		running.Add(1)
+		<-release
+		return 1, nil
+	}
+
+	assert.ErrorContains(t, executor.Resize(0, 1), "nonpositive core pool size")
+	assert.ErrorContains(t, executor.Resize(2, 1), "less than core pool size")
+
+	routines := runtime.NumGoroutine()
+	assert.IsNil(t, executor.Resize(3, 3))
+	time.Sleep(time.Millisecond)
+	futures := make([]async.Future[int], 3)
+	for i := range futures {
+		futures[i] = submitJob[int](t, executor, job)
+	}
+	time.Sleep(time.Millisecond)
+	assert.Equal(t, int32(3), running.Load())
+
+	// the excess workers are retired once idle
+	assert.IsNil(t, executor.Resize(1, 1))
+	close(release)
+	assertFutureResult(t, 1, futures...)
+	awaitGoroutines(t, routines, 100*time.Millisecond)
+
+	_ = executor.Shutdown()
+	assert.IsNil(t, executor.AwaitTermination(ctx))
+	assert.ErrorIs(t, executor.Resize(1, 1), async.ErrExecutorShutDown)
+}
+
+type recordingMetrics struct {
+	submitted, started, finished, rejected, panicked atomic.Int32
+}
+
+func (m *recordingMetrics) TaskSubmitted()                        { m.submitted.Add(1) }
+func (m *recordingMetrics) TaskStarted(_ time.Duration)           { m.started.Add(1) }
+func (m *recordingMetrics) TaskFinished(_ time.Duration, _ error) { m.finished.Add(1) }
+func (m *recordingMetrics) TaskRejected(_ error)                  { m.rejected.Add(1) }
+func (m *recordingMetrics) TaskPanicked(_ *async.PanicError)      { m.panicked.Add(1) }
+
+func TestExecutor_Stats(t *testing.T) {
+	ctx := t.Context()
+	metrics := &recordingMetrics{}
+	config := async.NewExecutorConfig(1, 1)
+	config.Metrics = metrics
+	executor := async.NewExecutor[int](ctx, config)
+
+	stats := executor.Stats()
+	assert.Equal(t, 1, stats.PoolSize)
+	assert.Equal(t, uint64(0), stats.Submitted)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	future1 := submitJob[int](t, executor, func(_ context.Context) (int, error) {
+		close(started)
+		<-release
+		return 1, nil
+	})
+	<-started
+	future2 := submitJob[int](t, executor, func(_ context.Context) (int, error) {
+		return 0, errors.New("task error")
+	})
+	_, err := executor.Submit(func(_ context.Context) (int, error) {
+		return 1, nil
+	})
+	assert.ErrorIs(t, err, async.ErrExecutorQueueFull)
+
+	stats = executor.Stats()
+	assert.Equal(t, 1, stats.QueueDepth)
+	assert.Equal(t, 1, stats.ActiveWorkers)
+	assert.Equal(t, uint64(3), stats.Submitted)
+	assert.Equal(t, uint64(1), stats.Rejected)
+
+	time.Sleep(5 * time.Millisecond)
+	close(release)
+	assertFutureResult(t, 1, future1)
+	_, err = future2.Join()
+	assert.ErrorContains(t, err, "task error")
+
+	future3 := submitJob[int](t, executor, func(_ context.Context) (int, error) {
+		panic("task panic")
+	})
+	_, err = future3.Join()
+	var panicErr *async.PanicError
+	assert.Equal(t, true, errors.As(err, &panicErr))
+
+	stats = executor.Stats()
+	assert.Equal(t, 0, stats.QueueDepth)
+	assert.Equal(t, uint64(4), stats.Submitted)
+	assert.Equal(t, uint64(1), stats.Completed)
+	assert.Equal(t, uint64(2), stats.Failed)
+	assert.Equal(t, uint64(1), stats.Rejected)
+	assert.Equal(t, uint64(1), stats.Panicked)
+	assert.Equal(t, int64(3), stats.QueueWait.Count)
+	// the second task has waited in the queue for the first one
+	assert.Equal(t, true, stats.QueueWait.Max >= 5*time.Millisecond)
+	assert.Equal(t, int64(3), stats.RunTime.Count)
+	assert.Equal(t, true, stats.RunTime.Max >= 5*time.Millisecond)
+	assert.Equal(t, stats.RunTime.Total/3, stats.RunTime.Mean())
+
+	assert.Equal(t, int32(4), metrics.submitted.Load())
+	assert.Equal(t, int32(3), metrics.started.Load())
+	assert.Equal(t, int32(3), metrics.finished.Load())
+	assert.Equal(t, int32(1), metrics.rejected.Load())
+	assert.Equal(t, int32(1), metrics.panicked.Load())
+
+	_ = executor.Shutdown()
+	assert.IsNil(t, executor.AwaitTermination(ctx))
+	assert.Equal(t, 0, executor.Stats().PoolSize)
+}
+
+// awaitGoroutines waits for the number of goroutines to drop to the
+// expected value, failing the test on timeout.
+func awaitGoroutines(t *testing.T, expected int, timeout time.Duration) {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for runtime.NumGoroutine() > expected {
+		if time.Now().After(deadline) {
+			t.Fatalf("%d goroutines, expected %d", runtime.NumGoroutine(), expected)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
 func submitJob[T any](t *testing.T, executor async.ExecutorService[T],
 	f func(context.Context) (T, error),
 ) async.Future[T] {
@@ -2,12 +2,40 @@ package async
 
 import (
 	"context"
+	"errors"
 	"sync"
+	"sync/atomic"
+)
+
+var (
+	// ErrCancelled is the error a Future is failed with when it is cancelled.
+	ErrCancelled = errors.New("async: future is cancelled")
+	// ErrNilFuture is the error a Future is failed with when a function
+	// expected to return a Future returns nil.
+	ErrNilFuture = errors.New("async: nil future")
+	// ErrNilError is the error a Future is failed with when it is created
+	// by Failed with a nil error.
+	ErrNilError = errors.New("async: nil error")
+)
+
+// FutureState represents the completion state of a [Future].
+type FutureState uint32
+
+const (
+	FutureStatePending FutureState = iota
+	FutureStateSucceeded
+	FutureStateFailed
+	FutureStateCancelled
 )
 
 // Future represents a value which may or may not currently be available,
 // but will be available at some point, or an error if that value could
 // not be made available.
+//
+// Combinators such as Map and Recover do not block any goroutine; the
+// supplied functions are invoked by the goroutine completing the Future,
+// or immediately if the Future has already been completed. If a supplied
+// function panics, the resulting Future fails with a [*PanicError].
 type Future[T any] interface {
 	// Map creates a new Future by applying a function to the successful
 	// result of this Future.
@@ -33,18 +61,79 @@ type Future[T any] interface {
 	// another Future.
 	RecoverWith(Future[T]) Future[T]
 
+	// RecoverFunc handles any error that this Future might contain using a
+	// resolver function receiving the error.
+	RecoverFunc(func(error) (T, error)) Future[T]
+
+	// RecoverIf handles an error that this Future might contain using a
+	// resolver function, if the error satisfies the predicate. Other errors
+	// are propagated as is.
+	RecoverIf(func(error) bool, func(error) (T, error)) Future[T]
+
+	// RecoverWithFunc handles any error that this Future might contain using
+	// a Future built by the given function. The function is invoked only if
+	// this Future fails.
+	RecoverWithFunc(func(error) Future[T]) Future[T]
+
+	// Cancel attempts to cancel the Future by failing it with [ErrCancelled].
+	// If the Future is bound to a cancellable computation, e.g. an [Executor]
+	// task, the computation is cancelled as well.
+	// Returns true if this call cancelled the Future, and false if the Future
+	// has already been completed.
+	Cancel() bool
+
+	// IsDone reports whether the Future is completed, without blocking.
+	IsDone() bool
+
+	// State returns the current completion state of the Future, without
+	// blocking. Only a Future completed by Cancel is reported as cancelled,
+	// while a failure with an error wrapping ErrCancelled is reported as
+	// failed.
+	State() FutureState
+
+	// TryGet returns the result of the Future without blocking.
+	// The last return value reports whether the Future is completed;
+	// if it is false, the value and the error must be ignored.
+	TryGet() (T, error, bool)
+
+	// Done returns a channel that is closed when the Future is completed.
+	// It can be used by any number of waiters, e.g. in select statements.
+	Done() <-chan struct{}
+
+	// OnComplete registers a callback to be invoked exactly once with the
+	// result of the Future when it is completed, or immediately if it has
+	// already been completed. If an executor is provided, the callback is
+	// submitted to it instead of being invoked inline. A panic in the
+	// callback is recovered and reported to the global [PanicHandler].
+	OnComplete(func(T, error), ...ExecutorService[T])
+
+	// OnSuccess registers a callback to be invoked with the value of the
+	// Future if it is completed successfully.
+	OnSuccess(func(T), ...ExecutorService[T])
+
+	// OnFailure registers a callback to be invoked with the error of the
+	// Future if it fails.
+	OnFailure(func(error), ...ExecutorService[T])
+
 	// complete completes the Future with either a value or an error.
+	// Returns true if this call completed the Future.
 	// It is used by [Promise] internally.
-	complete(T, error)
+	complete(T, error) bool
 }
 
 // futureImpl implements the Future interface.
 type futureImpl[T any] struct {
-	value        T
-	err          error
-	done         chan any
-	acceptOnce   sync.Once
-	completeOnce sync.Once
+	mtx  sync.Mutex
+	done chan struct{}
+	// result is set on completion, before the done channel is closed.
+	result atomic.Pointer[Result[T]]
+	// cancelled is set before the result if the Future is cancelled.
+	cancelled atomic.Bool
+	// callbacks are invoked on completion; guarded by mtx.
+	callbacks []func(T, error)
+	// cancelFunc is called when the Future is cancelled; it must be set
+	// before the Future is published.
+	cancelFunc func()
 }
 
 // Verify futureImpl satisfies the Future interface.
@@ -53,54 +142,43 @@ var _ Future[any] = (*futureImpl[any])(nil)
 // newFuture returns a new Future.
 func newFuture[T any]() Future[T] {
 	return &futureImpl[T]{
-		done: make(chan any, 1),
+		done: make(chan struct{}),
 	}
 }
 
-// accept blocks once, until the Future result is available.
-func (fut *futureImpl[T]) accept() {
-	fut.acceptOnce.Do(func() {
-		result := <-fut.done
-		fut.setResult(result)
-	})
+// Completed returns a new Future that is already completed with the given
+// value.
+func Completed[T any](value T) Future[T] {
+	future := newFuture[T]()
+	future.complete(value, nil)
+	return future
 }
 
-// acceptTimeout blocks once, until the Future result is available or until
-// the context is canceled.
-func (fut *futureImpl[T]) acceptContext(ctx context.Context) {
-	fut.acceptOnce.Do(func() {
-		select {
-		case result := <-fut.done:
-			fut.setResult(result)
-		case <-ctx.Done():
-			fut.setResult(ctx.Err())
-		}
-	})
-}
-
-// setResult assigns a value to the Future instance.
-func (fut *futureImpl[T]) setResult(result any) {
-	switch value := result.(type) {
-	case error:
-		fut.err = value
-	default:
-		fut.value = value.(T)
+// Failed returns a new Future that has already failed with the given error.
+// If err is nil, the Future fails with [ErrNilError].
+func Failed[T any](err error) Future[T] {
+	if err == nil {
+		err = ErrNilError
 	}
+	future := newFuture[T]()
+	var zero T
+	future.complete(zero, err)
+	return future
 }
 
 // Map creates a new Future by applying a function to the successful result
 // of this Future and returns the result of the function as a new Future.
 func (fut *futureImpl[T]) Map(f func(T) (T, error)) Future[T] {
 	next := newFuture[T]()
-	go func() {
-		fut.accept()
-		if fut.err != nil {
-			var zero T
-			next.complete(zero, fut.err)
-		} else {
-			next.complete(f(fut.value))
-		}
-	}()
+	fut.addCallback(func(value T, err error) {
+		next.complete(safeCall(func() (T, error) {
+			if err != nil {
+				var zero T
+				return zero, err
+			}
+			return f(value)
+		}))
+	})
 	return next
 }
 
@@ -108,77 +186,270 @@ func (fut *futureImpl[T]) Map(f func(T) (T, error)) Future[T] {
 // of this Future and returns the result of the function as a new Future.
 func (fut *futureImpl[T]) FlatMap(f func(T) (Future[T], error)) Future[T] {
 	next := newFuture[T]()
-	go func() {
-		fut.accept()
-		if fut.err != nil {
+	fut.addCallback(func(value T, err error) {
+		tfut, err := safeCall(func() (Future[T], error) {
+			if err != nil {
+				return nil, err
+			}
+			return f(value)
+		})
+		if err != nil {
 			var zero T
-			next.complete(zero, fut.err)
+			next.complete(zero, err)
 		} else {
-			tfut, terr := f(fut.value)
-			if terr != nil {
-				var zero T
-				next.complete(zero, terr)
-			} else {
-				next.complete(tfut.Join())
-			}
+			completeWith(next, tfut)
 		}
-	}()
+	})
 	return next
 }
 
 // Join blocks until the Future is completed and returns either
 // a result or an error.
 func (fut *futureImpl[T]) Join() (T, error) {
-	fut.accept()
-	return fut.value, fut.err
+	<-fut.done
+	return fut.result.Load().Unwrap()
 }
 
 // Get blocks until the Future is completed or context is canceled and
 // returns either a result or an error.
+// A canceled context only releases this caller; it does not affect the
+// result of the Future observed by other callers.
 func (fut *futureImpl[T]) Get(ctx context.Context) (T, error) {
-	fut.acceptContext(ctx)
-	return fut.value, fut.err
+	select {
+	case <-fut.done:
+		return fut.result.Load().Unwrap()
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
 }
 
 // Recover handles any error that this Future might contain using
 // a given resolver function.
 // Returns the result as a new Future.
 func (fut *futureImpl[T]) Recover(f func() (T, error)) Future[T] {
-	next := newFuture[T]()
-	go func() {
-		fut.accept()
-		if fut.err != nil {
-			next.complete(f())
-		} else {
-			next.complete(fut.value, nil)
-		}
-	}()
-	return next
+	return fut.RecoverFunc(func(_ error) (T, error) {
+		return f()
+	})
 }
 
 // RecoverWith handles any error that this Future might contain using
 // another Future.
 // Returns the result as a new Future.
 func (fut *futureImpl[T]) RecoverWith(rf Future[T]) Future[T] {
+	return fut.RecoverWithFunc(func(_ error) Future[T] {
+		return rf
+	})
+}
+
+// RecoverFunc handles any error that this Future might contain using
+// a given resolver function receiving the error.
+// Returns the result as a new Future.
+func (fut *futureImpl[T]) RecoverFunc(f func(error) (T, error)) Future[T] {
+	return fut.RecoverIf(func(_ error) bool { return true }, f)
+}
+
+// RecoverIf handles an error that this Future might contain using a given
+// resolver function, if the error satisfies the predicate.
+// Returns the result as a new Future.
+func (fut *futureImpl[T]) RecoverIf(predicate func(error) bool,
+	f func(error) (T, error),
+) Future[T] {
 	next := newFuture[T]()
-	go func() {
-		fut.accept()
-		if fut.err != nil {
-			next.complete(rf.Join())
+	fut.addCallback(func(value T, err error) {
+		next.complete(safeCall(func() (T, error) {
+			if err != nil && predicate(err) {
+				return f(err)
+			}
+			return value, err
+		}))
+	})
+	return next
+}
+
+// RecoverWithFunc handles any error that this Future might contain using
+// a Future built lazily by the given function.
+// Returns the result as a new Future.
+func (fut *futureImpl[T]) RecoverWithFunc(f func(error) Future[T]) Future[T] {
+	next := newFuture[T]()
+	fut.addCallback(func(value T, err error) {
+		if err == nil {
+			next.complete(value, nil)
+			return
+		}
+		rf, err := safeCall(func() (Future[T], error) {
+			return f(err), nil
+		})
+		if err != nil {
+			var zero T
+			next.complete(zero, err)
 		} else {
-			next.complete(fut.value, nil)
+			completeWith(next, rf)
 		}
-	}()
+	})
 	return next
 }
 
-// complete completes the Future with either a value or an error.
-func (fut *futureImpl[T]) complete(value T, err error) {
-	fut.completeOnce.Do(func() {
+// Cancel attempts to cancel the Future by failing it with ErrCancelled.
+// Returns true if this call cancelled the Future.
+func (fut *futureImpl[T]) Cancel() bool {
+	var zero T
+	if !fut.completeWithState(zero, ErrCancelled, true) {
+		return false
+	}
+	if fut.cancelFunc != nil {
+		fut.cancelFunc()
+	}
+	return true
+}
+
+// IsDone reports whether the Future is completed, without blocking.
+func (fut *futureImpl[T]) IsDone() bool {
+	return fut.result.Load() != nil
+}
+
+// State returns the current completion state of the Future, without blocking.
+func (fut *futureImpl[T]) State() FutureState {
+	result := fut.result.Load()
+	switch {
+	case result == nil:
+		return FutureStatePending
+	case result.Ok():
+		return FutureStateSucceeded
+	case fut.cancelled.Load():
+		return FutureStateCancelled
+	default:
+		return FutureStateFailed
+	}
+}
+
+// TryGet returns the result of the Future without blocking.
+// The last return value reports whether the Future is completed.
+func (fut *futureImpl[T]) TryGet() (T, error, bool) {
+	result := fut.result.Load()
+	if result == nil {
+		var zero T
+		return zero, nil, false
+	}
+	value, err := result.Unwrap()
+	return value, err, true
+}
+
+// Done returns a channel that is closed when the Future is completed.
+func (fut *futureImpl[T]) Done() <-chan struct{} {
+	return fut.done
+}
+
+// OnComplete registers a callback to be invoked exactly once with the result
+// of the Future when it is completed, or immediately if it has already been
+// completed.
+func (fut *futureImpl[T]) OnComplete(f func(T, error), executor ...ExecutorService[T]) {
+	// a panicking callback must not affect the other callbacks
+	safe := func(value T, err error) {
+		safeRun(func() { f(value, err) })
+	}
+	fut.addCallback(callbackOn(safe, executor))
+}
+
+// OnSuccess registers a callback to be invoked with the value of the Future
+// if it is completed successfully.
+func (fut *futureImpl[T]) OnSuccess(f func(T), executor ...ExecutorService[T]) {
+	fut.OnComplete(func(value T, err error) {
+		if err == nil {
+			f(value)
+		}
+	}, executor...)
+}
+
+// OnFailure registers a callback to be invoked with the error of the Future
+// if it fails.
+func (fut *futureImpl[T]) OnFailure(f func(error), executor ...ExecutorService[T]) {
+	fut.OnComplete(func(_ T, err error) {
 		if err != nil {
-			fut.done <- err
-		} else {
-			fut.done <- value
+			f(err)
 		}
+	}, executor...)
+}
+
+// addCallback registers a callback to be invoked on completion, or invokes
+// it immediately if the Future has already been completed.
+func (fut *futureImpl[T]) addCallback(f func(T, error)) {
+	fut.mtx.Lock()
+	if result := fut.result.Load(); result != nil {
+		fut.mtx.Unlock()
+		f(result.Unwrap())
+		return
+	}
+	fut.callbacks = append(fut.callbacks, f)
+	fut.mtx.Unlock()
+}
+
+// completeWith completes next with the result of the given Future once it
+// is completed.
+func completeWith[T any](next Future[T], future Future[T]) {
+	if future == nil {
+		var zero T
+		next.complete(zero, ErrNilFuture)
+		return
+	}
+	future.OnComplete(func(value T, err error) {
+		next.complete(value, err)
 	})
 }
+
+// callbackOn returns a callback that submits f to the first of the given
+// executors, falling back to invoking f inline if there is no executor or
+// the task is rejected or discarded without running.
+func callbackOn[T any](f func(T, error), executor []ExecutorService[T]) func(T, error) {
+	if len(executor) == 0 || executor[0] == nil {
+		return f
+	}
+	return func(value T, err error) {
+		var once sync.Once
+		invoke := func() {
+			once.Do(func() { f(value, err) })
+		}
+		// the callback task itself succeeds regardless of the watched result
+		future, submitErr := executor[0].Submit(func(_ context.Context) (T, error) {
+			invoke()
+			return value, nil
+		})
+		if submitErr != nil {
+			invoke()
+			return
+		}
+		// the task can be discarded by the rejection policy or the shutdown
+		future.OnFailure(func(error) { invoke() })
+	}
+}
+
+// complete completes the Future with either a value or an error.
+// Returns true if this call completed the Future.
+func (fut *futureImpl[T]) complete(value T, err error) bool {
+	return fut.completeWithState(value, err, false)
+}
+
+// completeWithState completes the Future, recording whether it has been
+// cancelled. Returns true if this call completed the Future.
+func (fut *futureImpl[T]) completeWithState(value T, err error, cancelled bool) bool {
+	fut.mtx.Lock()
+	if fut.result.Load() != nil {
+		fut.mtx.Unlock()
+		return false
+	}
+	if err != nil {
+		var zero T
+		value = zero
+	}
+	fut.cancelled.Store(cancelled)
+	fut.result.Store(&Result[T]{value: value, err: err})
+	close(fut.done)
+	callbacks := fut.callbacks
+	fut.callbacks = nil
+	fut.mtx.Unlock()
+
+	for _, callback := range callbacks {
+		callback(value, err)
+	}
+	return true
+}
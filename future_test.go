@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"runtime"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -53,6 +54,69 @@ func TestFuture_Utils(t *testing.T) {
 	assert.Equal(t, res, futRes)
 }
 
+func TestFuture_AllSettled(t *testing.T) {
+	p1 := async.NewPromise[int]()
+	p2 := async.NewPromise[int]()
+	err := errors.New("error")
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		p2.Failure(err)
+		p1.CompleteResult(async.NewResult(1, nil))
+	}()
+
+	res, futErr := async.FutureAllSettled(p1.Future(), p2.Future()).Join()
+	assert.IsNil(t, futErr)
+	assert.Equal(t, 2, len(res))
+
+	value, resErr := res[0].Unwrap()
+	assert.Equal(t, 1, value)
+	assert.IsNil(t, resErr)
+
+	assert.Equal(t, false, res[1].Ok())
+	assert.ErrorIs(t, res[1].Err(), err)
+}
+
+func TestFuture_All(t *testing.T) {
+	p1 := async.NewPromise[int]()
+	p2 := async.NewPromise[int]()
+	p3 := async.NewPromise[int]()
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		p3.Success(3)
+		p1.Success(1)
+		p2.Success(2)
+	}()
+
+	res, err := async.FutureAll(p1.Future(), p2.Future(), p3.Future()).Join()
+	assert.Equal(t, []int{1, 2, 3}, res)
+	assert.IsNil(t, err)
+
+	res, err = async.FutureAll[int]().Join()
+	assert.Equal(t, []int{}, res)
+	assert.IsNil(t, err)
+}
+
+func TestFuture_AllFailFast(t *testing.T) {
+	p1 := async.NewPromise[int]()
+	p2 := async.NewPromise[int]()
+	err := errors.New("error")
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		p2.Failure(err)
+	}()
+
+	res, futErr := async.FutureAll(p1.Future(), p2.Future()).Join()
+	assert.IsNil(t, res)
+	assert.ErrorIs(t, futErr, err)
+
+	p3 := async.NewPromise[int]()
+	_, futErr = async.FutureAllCancelOnFailure(p3.Future(), p2.Future()).Join()
+	assert.ErrorIs(t, futErr, err)
+
+	_, futErr = p3.Future().Join()
+	assert.ErrorIs(t, futErr, async.ErrCancelled)
+}
+
 func TestFuture_FirstCompleted(t *testing.T) {
 	p := async.NewPromise[*bool]()
 	go func() {
@@ -64,7 +128,75 @@ func TestFuture_FirstCompleted(t *testing.T) {
 	futRes, futErr := async.FutureFirstCompletedOf(p.Future(), timeout).Join()
 
 	assert.IsNil(t, futRes)
-	assert.NotEqual(t, futErr, nil)
+	assert.ErrorIs(t, futErr, async.ErrTimeout)
+}
+
+func TestFuture_WithTimeout(t *testing.T) {
+	p1 := async.NewPromise[int]()
+	future := async.WithTimeout(p1.Future(), 10*time.Millisecond)
+
+	_, err := future.Join()
+	assert.ErrorIs(t, err, async.ErrTimeout)
+
+	// the wrapped future is cancelled on timeout
+	_, err = p1.Future().Join()
+	assert.ErrorIs(t, err, async.ErrCancelled)
+
+	p2 := async.NewPromise[int]()
+	p2.Success(1)
+	res, err := async.WithDeadline(p2.Future(), time.Now().Add(time.Second)).Join()
+	assert.Equal(t, 1, res)
+	assert.IsNil(t, err)
+
+	p3 := async.NewPromise[int]()
+	_, err = async.WithDeadline(p3.Future(), time.Now()).Join()
+	assert.ErrorIs(t, err, async.ErrTimeout)
+
+	p4 := async.NewPromise[int]()
+	future = async.WithTimeout(p4.Future(), time.Second)
+	future.Cancel()
+	assert.Equal(t, async.FutureStateCancelled, p4.Future().State())
+}
+
+func TestFuture_Any(t *testing.T) {
+	p1 := async.NewPromise[int]()
+	p2 := async.NewPromise[int]()
+	p3 := async.NewPromise[int]()
+	go func() {
+		p1.Failure(errors.New("error1"))
+		time.Sleep(10 * time.Millisecond)
+		p2.Success(2)
+		time.Sleep(10 * time.Millisecond)
+		p3.Success(3)
+	}()
+
+	res, err := async.FutureAny(p1.Future(), p2.Future(), p3.Future()).Join()
+	assert.Equal(t, 2, res)
+	assert.IsNil(t, err)
+}
+
+func TestFuture_AnyFailure(t *testing.T) {
+	p1 := async.NewPromise[int]()
+	p2 := async.NewPromise[int]()
+	err1 := errors.New("error1")
+	err2 := errors.New("error2")
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		p2.Failure(err2)
+		p1.Failure(err1)
+	}()
+
+	_, err := async.FutureAny(p1.Future(), p2.Future()).Join()
+	assert.ErrorIs(t, err, err1)
+	assert.ErrorIs(t, err, err2)
+
+	var aggregateErr *async.AggregateError
+	assert.Equal(t, true, errors.As(err, &aggregateErr))
+	assert.Equal(t, []error{err1, err2}, aggregateErr.Errors)
+
+	_, err = async.FutureAny[int]().Join()
+	assert.Equal(t, true, errors.As(err, &aggregateErr))
+	assert.Equal(t, 0, len(aggregateErr.Errors))
 }
 
 func TestFuture_Transform(t *testing.T) {
@@ -93,6 +225,46 @@ func TestFuture_Transform(t *testing.T) {
 	assert.Equal(t, 3, *res)
 }
 
+func TestFuture_Then(t *testing.T) {
+	p := async.NewPromise[[]byte]()
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		p.Success([]byte("42"))
+	}()
+
+	length := async.Then(p.Future(), func(b []byte) (int, error) {
+		return len(b), nil
+	})
+	str := async.ThenFuture(p.Future(), func(b []byte) (async.Future[string], error) {
+		return async.NewTask(func() (string, error) {
+			return string(b), nil
+		}).Call(), nil
+	})
+	failed := async.Then(str, func(_ string) (int, error) {
+		return 0, errors.New("then error")
+	})
+	recovered := async.Transform(failed, func(_ int, err error) (string, error) {
+		return err.Error(), nil
+	})
+
+	res1, err := length.Join()
+	assert.Equal(t, 2, res1)
+	assert.IsNil(t, err)
+
+	res2, err := str.Join()
+	assert.Equal(t, "42", res2)
+	assert.IsNil(t, err)
+
+	_, err = async.Then(failed, func(_ int) (bool, error) {
+		return true, nil
+	}).Join()
+	assert.ErrorContains(t, err, "then error")
+
+	res3, err := recovered.Join()
+	assert.Equal(t, "then error", res3)
+	assert.IsNil(t, err)
+}
+
 func TestFuture_Recover(t *testing.T) {
 	p1 := async.NewPromise[int]()
 	p2 := async.NewPromise[int]()
@@ -122,6 +294,58 @@ func TestFuture_Recover(t *testing.T) {
 	assert.IsNil(t, err)
 }
 
+func TestFuture_RecoverFunc(t *testing.T) {
+	errFull := errors.New("full")
+	errInvalid := errors.New("invalid")
+
+	p1 := async.NewPromise[int]()
+	p1.Failure(errFull)
+	res, err := p1.Future().RecoverFunc(func(err error) (int, error) {
+		if errors.Is(err, errFull) {
+			return 1, nil
+		}
+		return 0, err
+	}).Join()
+	assert.Equal(t, 1, res)
+	assert.IsNil(t, err)
+
+	isFull := func(err error) bool { return errors.Is(err, errFull) }
+	recoverFull := func(_ error) (int, error) { return 2, nil }
+
+	res, err = p1.Future().RecoverIf(isFull, recoverFull).Join()
+	assert.Equal(t, 2, res)
+	assert.IsNil(t, err)
+
+	p2 := async.NewPromise[int]()
+	p2.Failure(errInvalid)
+	_, err = p2.Future().RecoverIf(isFull, recoverFull).Join()
+	assert.ErrorIs(t, err, errInvalid)
+}
+
+func TestFuture_RecoverWithFunc(t *testing.T) {
+	var calls atomic.Int32
+	fallback := func(err error) async.Future[string] {
+		calls.Add(1)
+		p := async.NewPromise[string]()
+		p.Success(err.Error())
+		return p.Future()
+	}
+
+	p1 := async.NewPromise[string]()
+	p1.Success("ok")
+	res, err := p1.Future().RecoverWithFunc(fallback).Join()
+	assert.Equal(t, "ok", res)
+	assert.IsNil(t, err)
+	assert.Equal(t, int32(0), calls.Load())
+
+	p2 := async.NewPromise[string]()
+	p2.Failure(errors.New("error"))
+	res, err = p2.Future().RecoverWithFunc(fallback).Join()
+	assert.Equal(t, "error", res)
+	assert.IsNil(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
 func TestFuture_Failure(t *testing.T) {
 	p1 := async.NewPromise[int]()
 	p2 := async.NewPromise[int]()
@@ -165,8 +389,260 @@ func TestFuture_Timeout(t *testing.T) {
 	_, err := future.Get(ctx)
 	assert.ErrorIs(t, err, context.DeadlineExceeded)
 
-	_, err = future.Join()
-	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	// the timed out waiter does not affect the result of the future
+	res, err := future.Join()
+	assert.Equal(t, true, res)
+	assert.IsNil(t, err)
+
+	res, err = future.Get(t.Context())
+	assert.Equal(t, true, res)
+	assert.IsNil(t, err)
+}
+
+func TestFuture_ConcurrentWaiters(t *testing.T) {
+	p := async.NewPromise[int]()
+	future := p.Future()
+
+	var wg sync.WaitGroup
+	results := make([]async.Result[int], 10)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// even waiters give up before the future is completed
+			timeout := time.Millisecond
+			if i%2 == 1 {
+				timeout = time.Second
+			}
+			ctx, cancel := context.WithTimeout(t.Context(), timeout)
+			defer cancel()
+			results[i] = async.NewResult(future.Get(ctx))
+		}()
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	p.Success(1)
+	wg.Wait()
+
+	for i, result := range results {
+		if i%2 == 0 {
+			assert.ErrorIs(t, result.Err(), context.DeadlineExceeded)
+		} else {
+			res, err := result.Unwrap()
+			assert.Equal(t, 1, res)
+			assert.IsNil(t, err)
+		}
+	}
+}
+
+func TestFuture_Cancel(t *testing.T) {
+	p := async.NewPromise[int]()
+	future := p.Future()
+
+	assert.Equal(t, true, future.Cancel())
+	p.Success(1)
+
+	res, err := future.Join()
+	assert.Equal(t, 0, res)
+	assert.ErrorIs(t, err, async.ErrCancelled)
+
+	completed := async.NewPromise[int]()
+	completed.Success(1)
+	assert.Equal(t, false, completed.Future().Cancel())
+
+	res, err = completed.Future().Join()
+	assert.Equal(t, 1, res)
+	assert.IsNil(t, err)
+}
+
+func TestFuture_State(t *testing.T) {
+	p1 := async.NewPromise[int]()
+	future := p1.Future()
+
+	assert.Equal(t, false, future.IsDone())
+	assert.Equal(t, async.FutureStatePending, future.State())
+	_, _, ok := future.TryGet()
+	assert.Equal(t, false, ok)
+
+	p1.Success(1)
+	assert.Equal(t, true, future.IsDone())
+	assert.Equal(t, async.FutureStateSucceeded, future.State())
+	res, err, ok := future.TryGet()
+	assert.Equal(t, 1, res)
+	assert.IsNil(t, err)
+	assert.Equal(t, true, ok)
+
+	// inspection does not consume the result
+	res, err = future.Join()
+	assert.Equal(t, 1, res)
+	assert.IsNil(t, err)
+
+	p2 := async.NewPromise[int]()
+	p2.Failure(errors.New("error"))
+	assert.Equal(t, async.FutureStateFailed, p2.Future().State())
+	_, err, ok = p2.Future().TryGet()
+	assert.ErrorContains(t, err, "error")
+	assert.Equal(t, true, ok)
+
+	p3 := async.NewPromise[int]()
+	p3.Future().Cancel()
+	assert.Equal(t, async.FutureStateCancelled, p3.Future().State())
+
+	// failures caused by a cancellation are not cancellations
+	mapped := p3.Future().Map(func(v int) (int, error) { return v, nil })
+	assert.Equal(t, async.FutureStateFailed, mapped.State())
+	p4 := async.NewPromise[int]()
+	p4.Failure(fmt.Errorf("task: %w", async.ErrCancelled))
+	assert.Equal(t, async.FutureStateFailed, p4.Future().State())
+}
+
+func TestFuture_Done(t *testing.T) {
+	p := async.NewPromise[int]()
+	future := p.Future()
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		p.Success(1)
+	}()
+
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case <-future.Done():
+			case <-time.After(time.Second):
+				t.Error("future is not done")
+			}
+		}()
+	}
+	wg.Wait()
+
+	res, err, ok := future.TryGet()
+	assert.Equal(t, 1, res)
+	assert.IsNil(t, err)
+	assert.Equal(t, true, ok)
+}
+
+func TestFuture_OnComplete(t *testing.T) {
+	p := async.NewPromise[int]()
+	future := p.Future()
+
+	var wg sync.WaitGroup
+	wg.Add(3)
+	var completed async.Result[int]
+	future.OnComplete(func(value int, err error) {
+		defer wg.Done()
+		completed = async.NewResult(value, err)
+	})
+	var succeeded int
+	future.OnSuccess(func(value int) {
+		defer wg.Done()
+		succeeded = value
+	})
+	future.OnFailure(func(_ error) {
+		t.Error("unexpected failure callback")
+	})
+	executor := async.NewExecutor[int](t.Context(), async.NewExecutorConfig(1, 1))
+	defer func() { _ = executor.Shutdown() }()
+	var executed atomic.Bool
+	future.OnSuccess(func(_ int) {
+		defer wg.Done()
+		executed.Store(true)
+	}, executor)
+
+	p.Success(1)
+	p.Success(2)
+	wg.Wait()
+
+	res, err := completed.Unwrap()
+	assert.Equal(t, 1, res)
+	assert.IsNil(t, err)
+	assert.Equal(t, 1, succeeded)
+	assert.Equal(t, true, executed.Load())
+
+	// callbacks registered after completion are invoked immediately
+	var failed error
+	failedPromise := async.NewPromise[int]()
+	failedPromise.Failure(errors.New("error"))
+	failedPromise.Future().OnFailure(func(err error) {
+		failed = err
+	})
+	assert.ErrorContains(t, failed, "error")
+}
+
+func TestFuture_OnCompleteDiscarded(t *testing.T) {
+	config := async.NewExecutorConfig(1, 0)
+	config.RejectionPolicy = async.RejectionPolicyDiscard
+	executor := async.NewExecutor[int](t.Context(), config)
+
+	// occupy the only worker, so that the callback task is discarded
+	started := make(chan struct{})
+	release := make(chan struct{})
+	blocking, err := executor.SubmitContext(t.Context(), func(_ context.Context) (int, error) {
+		close(started)
+		<-release
+		return 0, nil
+	})
+	assert.IsNil(t, err)
+	<-started
+
+	var calls atomic.Int32
+	failedPromise := async.NewPromise[int]()
+	failedPromise.Failure(errors.New("error"))
+	// the callback is invoked inline instead
+	failedPromise.Future().OnComplete(func(_ int, err error) {
+		calls.Add(1)
+		assert.ErrorContains(t, err, "error")
+	}, executor)
+	assert.Equal(t, int32(1), calls.Load())
+
+	close(release)
+	assertFutureResult(t, 0, blocking)
+	time.Sleep(time.Millisecond)
+
+	// the callback task does not count as failed
+	done := make(chan struct{})
+	failedPromise.Future().OnFailure(func(_ error) {
+		close(done)
+	}, executor)
+	<-done
+
+	_ = executor.Shutdown()
+	assert.IsNil(t, executor.AwaitTermination(t.Context()))
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Equal(t, uint64(0), executor.Stats().Failed)
+}
+
+func TestFuture_ChainGoroutineFree(t *testing.T) {
+	routines := runtime.NumGoroutine()
+
+	promises := make([]async.Promise[int], 100)
+	futures := make([]async.Future[int], len(promises))
+	for i := range promises {
+		promises[i] = async.NewPromise[int]()
+		future := promises[i].Future()
+		for range 10 {
+			future = future.Map(func(v int) (int, error) {
+				return v + 1, nil
+			}).Recover(func() (int, error) {
+				return 0, nil
+			})
+		}
+		futures[i] = future
+	}
+
+	// pending chains do not park any goroutines
+	assert.Equal(t, true, runtime.NumGoroutine() <= routines)
+
+	for i, promise := range promises {
+		promise.Success(i)
+	}
+	for i, future := range futures {
+		res, err := future.Join()
+		assert.Equal(t, i+10, res)
+		assert.IsNil(t, err)
+	}
 }
 
 func TestFuture_GoroutineLeak(t *testing.T) {
@@ -1,26 +1,168 @@
 package async
 
 import (
+	"errors"
 	"fmt"
+	"strings"
+	"sync/atomic"
 	"time"
 )
 
+// ErrTimeout is the error a Future is failed with when it is not completed
+// in time.
+var ErrTimeout = errors.New("async: future timeout")
+
+// AggregateError is the error a Future returned by [FutureAny] fails with
+// when all of the input Futures fail. Errors holds the failure of each
+// input Future by its index.
+type AggregateError struct {
+	Errors []error
+}
+
+// Error implements the error interface.
+func (e *AggregateError) Error() string {
+	var b strings.Builder
+	b.WriteString("async: all futures failed")
+	for i, err := range e.Errors {
+		fmt.Fprintf(&b, "\n[%d]: %v", i, err)
+	}
+	return b.String()
+}
+
+// Unwrap returns the underlying errors, allowing them to be inspected
+// using errors.Is and errors.As.
+func (e *AggregateError) Unwrap() []error {
+	return e.Errors
+}
+
+// Then creates a new Future by applying a function to the successful result
+// of the given Future. Unlike [Future.Map], the function may change the
+// type of the result.
+func Then[T, U any](future Future[T], f func(T) (U, error)) Future[U] {
+	return Transform(future, func(value T, err error) (U, error) {
+		if err != nil {
+			var zero U
+			return zero, err
+		}
+		return f(value)
+	})
+}
+
+// ThenFuture creates a new Future by applying a function to the successful
+// result of the given Future and flattening the returned Future. Unlike
+// [Future.FlatMap], the function may change the type of the result.
+func ThenFuture[T, U any](future Future[T], f func(T) (Future[U], error)) Future[U] {
+	next := newFuture[U]()
+	future.OnComplete(func(value T, err error) {
+		if err != nil {
+			var zero U
+			next.complete(zero, err)
+			return
+		}
+		ufut, err := safeCall(func() (Future[U], error) {
+			return f(value)
+		})
+		if err != nil {
+			var zero U
+			next.complete(zero, err)
+		} else {
+			completeWith(next, ufut)
+		}
+	})
+	return next
+}
+
+// Transform creates a new Future by applying a function to the result of
+// the given Future, whether it is a value or an error.
+func Transform[T, U any](future Future[T], f func(T, error) (U, error)) Future[U] {
+	next := newFuture[U]()
+	future.OnComplete(func(value T, err error) {
+		next.complete(safeCall(func() (U, error) {
+			return f(value, err)
+		}))
+	})
+	return next
+}
+
 // FutureSeq reduces many Futures into a single Future.
 // The resulting array may contain both T values and errors.
+// See [FutureAllSettled] for a typed alternative.
 func FutureSeq[T any](futures []Future[T]) Future[[]any] {
-	next := newFuture[[]any]()
-	go func() {
-		seq := make([]any, len(futures))
-		for i, future := range futures {
-			result, err := future.Join()
-			if err != nil {
-				seq[i] = err
+	results := FutureAllSettled(futures...)
+	return Then(results, func(results []Result[T]) ([]any, error) {
+		seq := make([]any, len(results))
+		for i, result := range results {
+			if result.Ok() {
+				seq[i], _ = result.Unwrap()
 			} else {
-				seq[i] = result
+				seq[i] = result.Err()
 			}
 		}
-		next.complete(seq, nil)
-	}()
+		return seq, nil
+	})
+}
+
+// FutureAllSettled reduces many Futures into a single Future holding the
+// Result of each input Future in the input order. The resulting Future
+// completes once all the input Futures are completed.
+func FutureAllSettled[T any](futures ...Future[T]) Future[[]Result[T]] {
+	next := newFuture[[]Result[T]]()
+	results := make([]Result[T], len(futures))
+	if len(futures) == 0 {
+		next.complete(results, nil)
+		return next
+	}
+	var remaining atomic.Int64
+	remaining.Store(int64(len(futures)))
+	for i, future := range futures {
+		future.OnComplete(func(value T, err error) {
+			results[i] = NewResult(value, err)
+			if remaining.Add(-1) == 0 {
+				next.complete(results, nil)
+			}
+		})
+	}
+	return next
+}
+
+// FutureAll reduces many Futures into a single Future holding their values
+// in the input order. The resulting Future fails fast with the first error
+// produced by any of the input Futures.
+func FutureAll[T any](futures ...Future[T]) Future[[]T] {
+	return futureAll(futures, false)
+}
+
+// FutureAllCancelOnFailure behaves like [FutureAll], but also cancels the
+// remaining input Futures once any of them fails.
+func FutureAllCancelOnFailure[T any](futures ...Future[T]) Future[[]T] {
+	return futureAll(futures, true)
+}
+
+func futureAll[T any](futures []Future[T], cancelOnFailure bool) Future[[]T] {
+	next := newFuture[[]T]()
+	if len(futures) == 0 {
+		next.complete([]T{}, nil)
+		return next
+	}
+	results := make([]T, len(futures))
+	var remaining atomic.Int64
+	remaining.Store(int64(len(futures)))
+	for i, future := range futures {
+		future.OnComplete(func(value T, err error) {
+			if err != nil {
+				if next.complete(nil, err) && cancelOnFailure {
+					for _, f := range futures {
+						f.Cancel()
+					}
+				}
+				return
+			}
+			results[i] = value
+			if remaining.Add(-1) == 0 {
+				next.complete(results, nil)
+			}
+		})
+	}
 	return next
 }
 
@@ -29,25 +171,88 @@ func FutureSeq[T any](futures []Future[T]) Future[[]any] {
 // This means no matter if it is completed as a success or as a failure.
 func FutureFirstCompletedOf[T any](futures ...Future[T]) Future[T] {
 	next := newFuture[T]()
-	go func() {
-		for _, f := range futures {
-			go func(future Future[T]) {
-				next.complete(future.Join())
-			}(f)
-		}
-	}()
+	for _, future := range futures {
+		completeWith(next, future)
+	}
+	return next
+}
+
+// FutureAny asynchronously returns a new Future to the result of the first
+// Future in the list that is completed successfully. If all of the Futures
+// fail, the resulting Future fails with an [*AggregateError].
+func FutureAny[T any](futures ...Future[T]) Future[T] {
+	next := newFuture[T]()
+	errs := make([]error, len(futures))
+	if len(futures) == 0 {
+		var zero T
+		next.complete(zero, &AggregateError{Errors: errs})
+		return next
+	}
+	var remaining atomic.Int64
+	remaining.Store(int64(len(futures)))
+	for i, future := range futures {
+		future.OnComplete(func(value T, err error) {
+			if err == nil {
+				next.complete(value, nil)
+				return
+			}
+			errs[i] = err
+			if remaining.Add(-1) == 0 {
+				var zero T
+				next.complete(zero, &AggregateError{Errors: errs})
+			}
+		})
+	}
 	return next
 }
 
 // FutureTimer returns Future that will have been resolved after given duration;
 // useful for FutureFirstCompletedOf for timeout purposes.
+// The Future fails with an error matching [ErrTimeout].
 func FutureTimer[T any](d time.Duration) Future[T] {
 	next := newFuture[T]()
-	go func() {
-		<-time.After(d)
+	timer := time.AfterFunc(d, func() {
+		var zero T
+		next.complete(zero, fmt.Errorf("%w after %s", ErrTimeout, d))
+	})
+	next.(*futureImpl[T]).cancelFunc = func() {
+		timer.Stop()
+	}
+	return next
+}
+
+// WithTimeout returns a new Future to the result of the given Future, which
+// fails with an error matching [ErrTimeout] if the given Future is not
+// completed within the timeout. On timeout, the given Future is cancelled.
+func WithTimeout[T any](future Future[T], timeout time.Duration) Future[T] {
+	return withTimer(future, timeout,
+		fmt.Errorf("%w after %s", ErrTimeout, timeout))
+}
+
+// WithDeadline returns a new Future to the result of the given Future, which
+// fails with an error matching [ErrTimeout] if the given Future is not
+// completed by the deadline. On timeout, the given Future is cancelled.
+func WithDeadline[T any](future Future[T], deadline time.Time) Future[T] {
+	return withTimer(future, time.Until(deadline),
+		fmt.Errorf("%w: deadline %s exceeded", ErrTimeout, deadline))
+}
+
+func withTimer[T any](future Future[T], d time.Duration, timeoutErr error) Future[T] {
+	next := newFuture[T]()
+	timer := time.AfterFunc(d, func() {
 		var zero T
-		next.(*futureImpl[T]).
-			complete(zero, fmt.Errorf("future timeout after %s", d))
-	}()
+		if next.complete(zero, timeoutErr) {
+			future.Cancel()
+		}
+	})
+	// cancelling the resulting Future cancels the given one
+	next.(*futureImpl[T]).cancelFunc = func() {
+		timer.Stop()
+		future.Cancel()
+	}
+	future.OnComplete(func(value T, err error) {
+		timer.Stop()
+		next.complete(value, err)
+	})
 	return next
 }
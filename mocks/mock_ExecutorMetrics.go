@@ -0,0 +1,238 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package async
+
+import (
+	"time"
+
+	"github.com/reugn/async"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockExecutorMetrics creates a new instance of MockExecutorMetrics. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockExecutorMetrics(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockExecutorMetrics {
+	mock := &MockExecutorMetrics{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockExecutorMetrics is an autogenerated mock type for the ExecutorMetrics type
+type MockExecutorMetrics struct {
+	mock.Mock
+}
+
+type MockExecutorMetrics_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockExecutorMetrics) EXPECT() *MockExecutorMetrics_Expecter {
+	return &MockExecutorMetrics_Expecter{mock: &_m.Mock}
+}
+
+// TaskFinished provides a mock function for the type MockExecutorMetrics
+func (_mock *MockExecutorMetrics) TaskFinished(runTime time.Duration, err error) {
+	_mock.Called(runTime, err)
+	return
+}
+
+// MockExecutorMetrics_TaskFinished_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TaskFinished'
+type MockExecutorMetrics_TaskFinished_Call struct {
+	*mock.Call
+}
+
+// TaskFinished is a helper method to define mock.On call
+//   - runTime time.Duration
+//   - err error
+func (_e *MockExecutorMetrics_Expecter) TaskFinished(runTime interface{}, err interface{}) *MockExecutorMetrics_TaskFinished_Call {
+	return &MockExecutorMetrics_TaskFinished_Call{Call: _e.mock.On("TaskFinished", runTime, err)}
+}
+
+func (_c *MockExecutorMetrics_TaskFinished_Call) Run(run func(runTime time.Duration, err error)) *MockExecutorMetrics_TaskFinished_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 time.Duration
+		if args[0] != nil {
+			arg0 = args[0].(time.Duration)
+		}
+		var arg1 error
+		if args[1] != nil {
+			arg1 = args[1].(error)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockExecutorMetrics_TaskFinished_Call) Return() *MockExecutorMetrics_TaskFinished_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockExecutorMetrics_TaskFinished_Call) RunAndReturn(run func(runTime time.Duration, err error)) *MockExecutorMetrics_TaskFinished_Call {
+	_c.Run(run)
+	return _c
+}
+
+// TaskPanicked provides a mock function for the type MockExecutorMetrics
+func (_mock *MockExecutorMetrics) TaskPanicked(panicError *async.PanicError) {
+	_mock.Called(panicError)
+	return
+}
+
+// MockExecutorMetrics_TaskPanicked_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TaskPanicked'
+type MockExecutorMetrics_TaskPanicked_Call struct {
+	*mock.Call
+}
+
+// TaskPanicked is a helper method to define mock.On call
+//   - panicError *async.PanicError
+func (_e *MockExecutorMetrics_Expecter) TaskPanicked(panicError interface{}) *MockExecutorMetrics_TaskPanicked_Call {
+	return &MockExecutorMetrics_TaskPanicked_Call{Call: _e.mock.On("TaskPanicked", panicError)}
+}
+
+func (_c *MockExecutorMetrics_TaskPanicked_Call) Run(run func(panicError *async.PanicError)) *MockExecutorMetrics_TaskPanicked_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *async.PanicError
+		if args[0] != nil {
+			arg0 = args[0].(*async.PanicError)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockExecutorMetrics_TaskPanicked_Call) Return() *MockExecutorMetrics_TaskPanicked_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockExecutorMetrics_TaskPanicked_Call) RunAndReturn(run func(panicError *async.PanicError)) *MockExecutorMetrics_TaskPanicked_Call {
+	_c.Run(run)
+	return _c
+}
+
+// TaskRejected provides a mock function for the type MockExecutorMetrics
+func (_mock *MockExecutorMetrics) TaskRejected(err error) {
+	_mock.Called(err)
+	return
+}
+
+// MockExecutorMetrics_TaskRejected_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TaskRejected'
+type MockExecutorMetrics_TaskRejected_Call struct {
+	*mock.Call
+}
+
+// TaskRejected is a helper method to define mock.On call
+//   - err error
+func (_e *MockExecutorMetrics_Expecter) TaskRejected(err interface{}) *MockExecutorMetrics_TaskRejected_Call {
+	return &MockExecutorMetrics_TaskRejected_Call{Call: _e.mock.On("TaskRejected", err)}
+}
+
+func (_c *MockExecutorMetrics_TaskRejected_Call) Run(run func(err error)) *MockExecutorMetrics_TaskRejected_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 error
+		if args[0] != nil {
+			arg0 = args[0].(error)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockExecutorMetrics_TaskRejected_Call) Return() *MockExecutorMetrics_TaskRejected_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockExecutorMetrics_TaskRejected_Call) RunAndReturn(run func(err error)) *MockExecutorMetrics_TaskRejected_Call {
+	_c.Run(run)
+	return _c
+}
+
+// TaskStarted provides a mock function for the type MockExecutorMetrics
+func (_mock *MockExecutorMetrics) TaskStarted(queueWait time.Duration) {
+	_mock.Called(queueWait)
+	return
+}
+
+// MockExecutorMetrics_TaskStarted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TaskStarted'
+type MockExecutorMetrics_TaskStarted_Call struct {
+	*mock.Call
+}
+
+// TaskStarted is a helper method to define mock.On call
+//   - queueWait time.Duration
+func (_e *MockExecutorMetrics_Expecter) TaskStarted(queueWait interface{}) *MockExecutorMetrics_TaskStarted_Call {
+	return &MockExecutorMetrics_TaskStarted_Call{Call: _e.mock.On("TaskStarted", queueWait)}
+}
+
+func (_c *MockExecutorMetrics_TaskStarted_Call) Run(run func(queueWait time.Duration)) *MockExecutorMetrics_TaskStarted_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 time.Duration
+		if args[0] != nil {
+			arg0 = args[0].(time.Duration)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockExecutorMetrics_TaskStarted_Call) Return() *MockExecutorMetrics_TaskStarted_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockExecutorMetrics_TaskStarted_Call) RunAndReturn(run func(queueWait time.Duration)) *MockExecutorMetrics_TaskStarted_Call {
+	_c.Run(run)
+	return _c
+}
+
+// TaskSubmitted provides a mock function for the type MockExecutorMetrics
+func (_mock *MockExecutorMetrics) TaskSubmitted() {
+	_mock.Called()
+	return
+}
+
+// MockExecutorMetrics_TaskSubmitted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TaskSubmitted'
+type MockExecutorMetrics_TaskSubmitted_Call struct {
+	*mock.Call
+}
+
+// TaskSubmitted is a helper method to define mock.On call
+func (_e *MockExecutorMetrics_Expecter) TaskSubmitted() *MockExecutorMetrics_TaskSubmitted_Call {
+	return &MockExecutorMetrics_TaskSubmitted_Call{Call: _e.mock.On("TaskSubmitted")}
+}
+
+func (_c *MockExecutorMetrics_TaskSubmitted_Call) Run(run func()) *MockExecutorMetrics_TaskSubmitted_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockExecutorMetrics_TaskSubmitted_Call) Return() *MockExecutorMetrics_TaskSubmitted_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockExecutorMetrics_TaskSubmitted_Call) RunAndReturn(run func()) *MockExecutorMetrics_TaskSubmitted_Call {
+	_c.Run(run)
+	return _c
+}
@@ -38,6 +38,96 @@ func (_m *MockFuture[T]) EXPECT() *MockFuture_Expecter[T] {
 	return &MockFuture_Expecter[T]{mock: &_m.Mock}
 }
 
+// Cancel provides a mock function for the type MockFuture
+func (_mock *MockFuture[T]) Cancel() bool {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Cancel")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func() bool); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// MockFuture_Cancel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Cancel'
+type MockFuture_Cancel_Call[T any] struct {
+	*mock.Call
+}
+
+// Cancel is a helper method to define mock.On call
+func (_e *MockFuture_Expecter[T]) Cancel() *MockFuture_Cancel_Call[T] {
+	return &MockFuture_Cancel_Call[T]{Call: _e.mock.On("Cancel")}
+}
+
+func (_c *MockFuture_Cancel_Call[T]) Run(run func()) *MockFuture_Cancel_Call[T] {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockFuture_Cancel_Call[T]) Return(b bool) *MockFuture_Cancel_Call[T] {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *MockFuture_Cancel_Call[T]) RunAndReturn(run func() bool) *MockFuture_Cancel_Call[T] {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Done provides a mock function for the type MockFuture
+func (_mock *MockFuture[T]) Done() <-chan struct{} {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Done")
+	}
+
+	var r0 <-chan struct{}
+	if returnFunc, ok := ret.Get(0).(func() <-chan struct{}); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan struct{})
+		}
+	}
+	return r0
+}
+
+// MockFuture_Done_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Done'
+type MockFuture_Done_Call[T any] struct {
+	*mock.Call
+}
+
+// Done is a helper method to define mock.On call
+func (_e *MockFuture_Expecter[T]) Done() *MockFuture_Done_Call[T] {
+	return &MockFuture_Done_Call[T]{Call: _e.mock.On("Done")}
+}
+
+func (_c *MockFuture_Done_Call[T]) Run(run func()) *MockFuture_Done_Call[T] {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockFuture_Done_Call[T]) Return(valCh <-chan struct{}) *MockFuture_Done_Call[T] {
+	_c.Call.Return(valCh)
+	return _c
+}
+
+func (_c *MockFuture_Done_Call[T]) RunAndReturn(run func() <-chan struct{}) *MockFuture_Done_Call[T] {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FlatMap provides a mock function for the type MockFuture
 func (_mock *MockFuture[T]) FlatMap(fn func(T) (async.Future[T], error)) async.Future[T] {
 	ret := _mock.Called(fn)
@@ -153,6 +243,50 @@ func (_c *MockFuture_Get_Call[T]) RunAndReturn(run func(context1 context.Context
 	return _c
 }
 
+// IsDone provides a mock function for the type MockFuture
+func (_mock *MockFuture[T]) IsDone() bool {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsDone")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func() bool); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// MockFuture_IsDone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsDone'
+type MockFuture_IsDone_Call[T any] struct {
+	*mock.Call
+}
+
+// IsDone is a helper method to define mock.On call
+func (_e *MockFuture_Expecter[T]) IsDone() *MockFuture_IsDone_Call[T] {
+	return &MockFuture_IsDone_Call[T]{Call: _e.mock.On("IsDone")}
+}
+
+func (_c *MockFuture_IsDone_Call[T]) Run(run func()) *MockFuture_IsDone_Call[T] {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockFuture_IsDone_Call[T]) Return(b bool) *MockFuture_IsDone_Call[T] {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *MockFuture_IsDone_Call[T]) RunAndReturn(run func() bool) *MockFuture_IsDone_Call[T] {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Join provides a mock function for the type MockFuture
 func (_mock *MockFuture[T]) Join() (T, error) {
 	ret := _mock.Called()
@@ -261,6 +395,183 @@ func (_c *MockFuture_Map_Call[T]) RunAndReturn(run func(fn func(T) (T, error)) a
 	return _c
 }
 
+// OnComplete provides a mock function for the type MockFuture
+func (_mock *MockFuture[T]) OnComplete(fn func(T, error), executorServices ...async.ExecutorService[T]) {
+	// async.ExecutorService[T]
+	_va := make([]interface{}, len(executorServices))
+	for _i := range executorServices {
+		_va[_i] = executorServices[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, fn)
+	_ca = append(_ca, _va...)
+	_mock.Called(_ca...)
+	return
+}
+
+// MockFuture_OnComplete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OnComplete'
+type MockFuture_OnComplete_Call[T any] struct {
+	*mock.Call
+}
+
+// OnComplete is a helper method to define mock.On call
+//   - fn func(T, error)
+//   - executorServices ...async.ExecutorService[T]
+func (_e *MockFuture_Expecter[T]) OnComplete(fn interface{}, executorServices ...interface{}) *MockFuture_OnComplete_Call[T] {
+	return &MockFuture_OnComplete_Call[T]{Call: _e.mock.On("OnComplete",
+		append([]interface{}{fn}, executorServices...)...)}
+}
+
+func (_c *MockFuture_OnComplete_Call[T]) Run(run func(fn func(T, error), executorServices ...async.ExecutorService[T])) *MockFuture_OnComplete_Call[T] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 func(T, error)
+		if args[0] != nil {
+			arg0 = args[0].(func(T, error))
+		}
+		var arg1 []async.ExecutorService[T]
+		variadicArgs := make([]async.ExecutorService[T], len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(async.ExecutorService[T])
+			}
+		}
+		arg1 = variadicArgs
+		run(
+			arg0,
+			arg1...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFuture_OnComplete_Call[T]) Return() *MockFuture_OnComplete_Call[T] {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockFuture_OnComplete_Call[T]) RunAndReturn(run func(fn func(T, error), executorServices ...async.ExecutorService[T])) *MockFuture_OnComplete_Call[T] {
+	_c.Run(run)
+	return _c
+}
+
+// OnFailure provides a mock function for the type MockFuture
+func (_mock *MockFuture[T]) OnFailure(fn func(error), executorServices ...async.ExecutorService[T]) {
+	// async.ExecutorService[T]
+	_va := make([]interface{}, len(executorServices))
+	for _i := range executorServices {
+		_va[_i] = executorServices[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, fn)
+	_ca = append(_ca, _va...)
+	_mock.Called(_ca...)
+	return
+}
+
+// MockFuture_OnFailure_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OnFailure'
+type MockFuture_OnFailure_Call[T any] struct {
+	*mock.Call
+}
+
+// OnFailure is a helper method to define mock.On call
+//   - fn func(error)
+//   - executorServices ...async.ExecutorService[T]
+func (_e *MockFuture_Expecter[T]) OnFailure(fn interface{}, executorServices ...interface{}) *MockFuture_OnFailure_Call[T] {
+	return &MockFuture_OnFailure_Call[T]{Call: _e.mock.On("OnFailure",
+		append([]interface{}{fn}, executorServices...)...)}
+}
+
+func (_c *MockFuture_OnFailure_Call[T]) Run(run func(fn func(error), executorServices ...async.ExecutorService[T])) *MockFuture_OnFailure_Call[T] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 func(error)
+		if args[0] != nil {
+			arg0 = args[0].(func(error))
+		}
+		var arg1 []async.ExecutorService[T]
+		variadicArgs := make([]async.ExecutorService[T], len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(async.ExecutorService[T])
+			}
+		}
+		arg1 = variadicArgs
+		run(
+			arg0,
+			arg1...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFuture_OnFailure_Call[T]) Return() *MockFuture_OnFailure_Call[T] {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockFuture_OnFailure_Call[T]) RunAndReturn(run func(fn func(error), executorServices ...async.ExecutorService[T])) *MockFuture_OnFailure_Call[T] {
+	_c.Run(run)
+	return _c
+}
+
+// OnSuccess provides a mock function for the type MockFuture
+func (_mock *MockFuture[T]) OnSuccess(fn func(T), executorServices ...async.ExecutorService[T]) {
+	// async.ExecutorService[T]
+	_va := make([]interface{}, len(executorServices))
+	for _i := range executorServices {
+		_va[_i] = executorServices[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, fn)
+	_ca = append(_ca, _va...)
+	_mock.Called(_ca...)
+	return
+}
+
+// MockFuture_OnSuccess_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OnSuccess'
+type MockFuture_OnSuccess_Call[T any] struct {
+	*mock.Call
+}
+
+// OnSuccess is a helper method to define mock.On call
+//   - fn func(T)
+//   - executorServices ...async.ExecutorService[T]
+func (_e *MockFuture_Expecter[T]) OnSuccess(fn interface{}, executorServices ...interface{}) *MockFuture_OnSuccess_Call[T] {
+	return &MockFuture_OnSuccess_Call[T]{Call: _e.mock.On("OnSuccess",
+		append([]interface{}{fn}, executorServices...)...)}
+}
+
+func (_c *MockFuture_OnSuccess_Call[T]) Run(run func(fn func(T), executorServices ...async.ExecutorService[T])) *MockFuture_OnSuccess_Call[T] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 func(T)
+		if args[0] != nil {
+			arg0 = args[0].(func(T))
+		}
+		var arg1 []async.ExecutorService[T]
+		variadicArgs := make([]async.ExecutorService[T], len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(async.ExecutorService[T])
+			}
+		}
+		arg1 = variadicArgs
+		run(
+			arg0,
+			arg1...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFuture_OnSuccess_Call[T]) Return() *MockFuture_OnSuccess_Call[T] {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockFuture_OnSuccess_Call[T]) RunAndReturn(run func(fn func(T), executorServices ...async.ExecutorService[T])) *MockFuture_OnSuccess_Call[T] {
+	_c.Run(run)
+	return _c
+}
+
 // Recover provides a mock function for the type MockFuture
 func (_mock *MockFuture[T]) Recover(fn func() (T, error)) async.Future[T] {
 	ret := _mock.Called(fn)
@@ -314,6 +625,118 @@ func (_c *MockFuture_Recover_Call[T]) RunAndReturn(run func(fn func() (T, error)
 	return _c
 }
 
+// RecoverFunc provides a mock function for the type MockFuture
+func (_mock *MockFuture[T]) RecoverFunc(fn func(error) (T, error)) async.Future[T] {
+	ret := _mock.Called(fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecoverFunc")
+	}
+
+	var r0 async.Future[T]
+	if returnFunc, ok := ret.Get(0).(func(func(error) (T, error)) async.Future[T]); ok {
+		r0 = returnFunc(fn)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(async.Future[T])
+		}
+	}
+	return r0
+}
+
+// MockFuture_RecoverFunc_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecoverFunc'
+type MockFuture_RecoverFunc_Call[T any] struct {
+	*mock.Call
+}
+
+// RecoverFunc is a helper method to define mock.On call
+//   - fn func(error) (T, error)
+func (_e *MockFuture_Expecter[T]) RecoverFunc(fn interface{}) *MockFuture_RecoverFunc_Call[T] {
+	return &MockFuture_RecoverFunc_Call[T]{Call: _e.mock.On("RecoverFunc", fn)}
+}
+
+func (_c *MockFuture_RecoverFunc_Call[T]) Run(run func(fn func(error) (T, error))) *MockFuture_RecoverFunc_Call[T] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 func(error) (T, error)
+		if args[0] != nil {
+			arg0 = args[0].(func(error) (T, error))
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFuture_RecoverFunc_Call[T]) Return(future async.Future[T]) *MockFuture_RecoverFunc_Call[T] {
+	_c.Call.Return(future)
+	return _c
+}
+
+func (_c *MockFuture_RecoverFunc_Call[T]) RunAndReturn(run func(fn func(error) (T, error)) async.Future[T]) *MockFuture_RecoverFunc_Call[T] {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecoverIf provides a mock function for the type MockFuture
+func (_mock *MockFuture[T]) RecoverIf(fn func(error) bool, fn1 func(error) (T, error)) async.Future[T] {
+	ret := _mock.Called(fn, fn1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecoverIf")
+	}
+
+	var r0 async.Future[T]
+	if returnFunc, ok := ret.Get(0).(func(func(error) bool, func(error) (T, error)) async.Future[T]); ok {
+		r0 = returnFunc(fn, fn1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(async.Future[T])
+		}
+	}
+	return r0
+}
+
+// MockFuture_RecoverIf_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecoverIf'
+type MockFuture_RecoverIf_Call[T any] struct {
+	*mock.Call
+}
+
+// RecoverIf is a helper method to define mock.On call
+//   - fn func(error) bool
+//   - fn1 func(error) (T, error)
+func (_e *MockFuture_Expecter[T]) RecoverIf(fn interface{}, fn1 interface{}) *MockFuture_RecoverIf_Call[T] {
+	return &MockFuture_RecoverIf_Call[T]{Call: _e.mock.On("RecoverIf", fn, fn1)}
+}
+
+func (_c *MockFuture_RecoverIf_Call[T]) Run(run func(fn func(error) bool, fn1 func(error) (T, error))) *MockFuture_RecoverIf_Call[T] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 func(error) bool
+		if args[0] != nil {
+			arg0 = args[0].(func(error) bool)
+		}
+		var arg1 func(error) (T, error)
+		if args[1] != nil {
+			arg1 = args[1].(func(error) (T, error))
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFuture_RecoverIf_Call[T]) Return(future async.Future[T]) *MockFuture_RecoverIf_Call[T] {
+	_c.Call.Return(future)
+	return _c
+}
+
+func (_c *MockFuture_RecoverIf_Call[T]) RunAndReturn(run func(fn func(error) bool, fn1 func(error) (T, error)) async.Future[T]) *MockFuture_RecoverIf_Call[T] {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RecoverWith provides a mock function for the type MockFuture
 func (_mock *MockFuture[T]) RecoverWith(future async.Future[T]) async.Future[T] {
 	ret := _mock.Called(future)
@@ -367,10 +790,179 @@ func (_c *MockFuture_RecoverWith_Call[T]) RunAndReturn(run func(future async.Fut
 	return _c
 }
 
+// RecoverWithFunc provides a mock function for the type MockFuture
+func (_mock *MockFuture[T]) RecoverWithFunc(fn func(error) async.Future[T]) async.Future[T] {
+	ret := _mock.Called(fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecoverWithFunc")
+	}
+
+	var r0 async.Future[T]
+	if returnFunc, ok := ret.Get(0).(func(func(error) async.Future[T]) async.Future[T]); ok {
+		r0 = returnFunc(fn)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(async.Future[T])
+		}
+	}
+	return r0
+}
+
+// MockFuture_RecoverWithFunc_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecoverWithFunc'
+type MockFuture_RecoverWithFunc_Call[T any] struct {
+	*mock.Call
+}
+
+// RecoverWithFunc is a helper method to define mock.On call
+//   - fn func(error) async.Future[T]
+func (_e *MockFuture_Expecter[T]) RecoverWithFunc(fn interface{}) *MockFuture_RecoverWithFunc_Call[T] {
+	return &MockFuture_RecoverWithFunc_Call[T]{Call: _e.mock.On("RecoverWithFunc", fn)}
+}
+
+func (_c *MockFuture_RecoverWithFunc_Call[T]) Run(run func(fn func(error) async.Future[T])) *MockFuture_RecoverWithFunc_Call[T] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 func(error) async.Future[T]
+		if args[0] != nil {
+			arg0 = args[0].(func(error) async.Future[T])
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFuture_RecoverWithFunc_Call[T]) Return(future async.Future[T]) *MockFuture_RecoverWithFunc_Call[T] {
+	_c.Call.Return(future)
+	return _c
+}
+
+func (_c *MockFuture_RecoverWithFunc_Call[T]) RunAndReturn(run func(fn func(error) async.Future[T]) async.Future[T]) *MockFuture_RecoverWithFunc_Call[T] {
+	_c.Call.Return(run)
+	return _c
+}
+
+// State provides a mock function for the type MockFuture
+func (_mock *MockFuture[T]) State() async.FutureState {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for State")
+	}
+
+	var r0 async.FutureState
+	if returnFunc, ok := ret.Get(0).(func() async.FutureState); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(async.FutureState)
+	}
+	return r0
+}
+
+// MockFuture_State_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'State'
+type MockFuture_State_Call[T any] struct {
+	*mock.Call
+}
+
+// State is a helper method to define mock.On call
+func (_e *MockFuture_Expecter[T]) State() *MockFuture_State_Call[T] {
+	return &MockFuture_State_Call[T]{Call: _e.mock.On("State")}
+}
+
+func (_c *MockFuture_State_Call[T]) Run(run func()) *MockFuture_State_Call[T] {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockFuture_State_Call[T]) Return(futureState async.FutureState) *MockFuture_State_Call[T] {
+	_c.Call.Return(futureState)
+	return _c
+}
+
+func (_c *MockFuture_State_Call[T]) RunAndReturn(run func() async.FutureState) *MockFuture_State_Call[T] {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TryGet provides a mock function for the type MockFuture
+func (_mock *MockFuture[T]) TryGet() (T, error, bool) {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for TryGet")
+	}
+
+	var r0 T
+	var r1 error
+	var r2 bool
+	if returnFunc, ok := ret.Get(0).(func() (T, error, bool)); ok {
+		return returnFunc()
+	}
+	if returnFunc, ok := ret.Get(0).(func() T); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(T)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
+	} else {
+		r1 = ret.Error(1)
+	}
+	if returnFunc, ok := ret.Get(2).(func() bool); ok {
+		r2 = returnFunc()
+	} else {
+		r2 = ret.Get(2).(bool)
+	}
+	return r0, r1, r2
+}
+
+// MockFuture_TryGet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TryGet'
+type MockFuture_TryGet_Call[T any] struct {
+	*mock.Call
+}
+
+// TryGet is a helper method to define mock.On call
+func (_e *MockFuture_Expecter[T]) TryGet() *MockFuture_TryGet_Call[T] {
+	return &MockFuture_TryGet_Call[T]{Call: _e.mock.On("TryGet")}
+}
+
+func (_c *MockFuture_TryGet_Call[T]) Run(run func()) *MockFuture_TryGet_Call[T] {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockFuture_TryGet_Call[T]) Return(v T, err error, b bool) *MockFuture_TryGet_Call[T] {
+	_c.Call.Return(v, err, b)
+	return _c
+}
+
+func (_c *MockFuture_TryGet_Call[T]) RunAndReturn(run func() (T, error, bool)) *MockFuture_TryGet_Call[T] {
+	_c.Call.Return(run)
+	return _c
+}
+
 // complete provides a mock function for the type MockFuture
-func (_mock *MockFuture[T]) complete(v T, err error) {
-	_mock.Called(v, err)
-	return
+func (_mock *MockFuture[T]) complete(v T, err error) bool {
+	ret := _mock.Called(v, err)
+
+	if len(ret) == 0 {
+		panic("no return value specified for complete")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func(T, error) bool); ok {
+		r0 = returnFunc(v, err)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
 }
 
 // MockFuture_complete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'complete'
@@ -403,12 +995,12 @@ func (_c *MockFuture_complete_Call[T]) Run(run func(v T, err error)) *MockFuture
 	return _c
 }
 
-func (_c *MockFuture_complete_Call[T]) Return() *MockFuture_complete_Call[T] {
-	_c.Call.Return()
+func (_c *MockFuture_complete_Call[T]) Return(b bool) *MockFuture_complete_Call[T] {
+	_c.Call.Return(b)
 	return _c
 }
 
-func (_c *MockFuture_complete_Call[T]) RunAndReturn(run func(v T, err error)) *MockFuture_complete_Call[T] {
-	_c.Run(run)
+func (_c *MockFuture_complete_Call[T]) RunAndReturn(run func(v T, err error) bool) *MockFuture_complete_Call[T] {
+	_c.Call.Return(run)
 	return _c
 }
@@ -36,6 +36,132 @@ func (_m *MockPromise[T]) EXPECT() *MockPromise_Expecter[T] {
 	return &MockPromise_Expecter[T]{mock: &_m.Mock}
 }
 
+// Complete provides a mock function for the type MockPromise
+func (_mock *MockPromise[T]) Complete(v T, err error) {
+	_mock.Called(v, err)
+	return
+}
+
+// MockPromise_Complete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Complete'
+type MockPromise_Complete_Call[T any] struct {
+	*mock.Call
+}
+
+// Complete is a helper method to define mock.On call
+//   - v T
+//   - err error
+func (_e *MockPromise_Expecter[T]) Complete(v interface{}, err interface{}) *MockPromise_Complete_Call[T] {
+	return &MockPromise_Complete_Call[T]{Call: _e.mock.On("Complete", v, err)}
+}
+
+func (_c *MockPromise_Complete_Call[T]) Run(run func(v T, err error)) *MockPromise_Complete_Call[T] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 T
+		if args[0] != nil {
+			arg0 = args[0].(T)
+		}
+		var arg1 error
+		if args[1] != nil {
+			arg1 = args[1].(error)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPromise_Complete_Call[T]) Return() *MockPromise_Complete_Call[T] {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockPromise_Complete_Call[T]) RunAndReturn(run func(v T, err error)) *MockPromise_Complete_Call[T] {
+	_c.Run(run)
+	return _c
+}
+
+// CompleteResult provides a mock function for the type MockPromise
+func (_mock *MockPromise[T]) CompleteResult(result async.Result[T]) {
+	_mock.Called(result)
+	return
+}
+
+// MockPromise_CompleteResult_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompleteResult'
+type MockPromise_CompleteResult_Call[T any] struct {
+	*mock.Call
+}
+
+// CompleteResult is a helper method to define mock.On call
+//   - result async.Result[T]
+func (_e *MockPromise_Expecter[T]) CompleteResult(result interface{}) *MockPromise_CompleteResult_Call[T] {
+	return &MockPromise_CompleteResult_Call[T]{Call: _e.mock.On("CompleteResult", result)}
+}
+
+func (_c *MockPromise_CompleteResult_Call[T]) Run(run func(result async.Result[T])) *MockPromise_CompleteResult_Call[T] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 async.Result[T]
+		if args[0] != nil {
+			arg0 = args[0].(async.Result[T])
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPromise_CompleteResult_Call[T]) Return() *MockPromise_CompleteResult_Call[T] {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockPromise_CompleteResult_Call[T]) RunAndReturn(run func(result async.Result[T])) *MockPromise_CompleteResult_Call[T] {
+	_c.Run(run)
+	return _c
+}
+
+// CompleteWith provides a mock function for the type MockPromise
+func (_mock *MockPromise[T]) CompleteWith(future async.Future[T]) {
+	_mock.Called(future)
+	return
+}
+
+// MockPromise_CompleteWith_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompleteWith'
+type MockPromise_CompleteWith_Call[T any] struct {
+	*mock.Call
+}
+
+// CompleteWith is a helper method to define mock.On call
+//   - future async.Future[T]
+func (_e *MockPromise_Expecter[T]) CompleteWith(future interface{}) *MockPromise_CompleteWith_Call[T] {
+	return &MockPromise_CompleteWith_Call[T]{Call: _e.mock.On("CompleteWith", future)}
+}
+
+func (_c *MockPromise_CompleteWith_Call[T]) Run(run func(future async.Future[T])) *MockPromise_CompleteWith_Call[T] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 async.Future[T]
+		if args[0] != nil {
+			arg0 = args[0].(async.Future[T])
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPromise_CompleteWith_Call[T]) Return() *MockPromise_CompleteWith_Call[T] {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockPromise_CompleteWith_Call[T]) RunAndReturn(run func(future async.Future[T])) *MockPromise_CompleteWith_Call[T] {
+	_c.Run(run)
+	return _c
+}
+
 // Failure provides a mock function for the type MockPromise
 func (_mock *MockPromise[T]) Failure(err error) {
 	_mock.Called(err)
@@ -161,3 +287,105 @@ func (_c *MockPromise_Success_Call[T]) RunAndReturn(run func(v T)) *MockPromise_
 	_c.Run(run)
 	return _c
 }
+
+// TryFailure provides a mock function for the type MockPromise
+func (_mock *MockPromise[T]) TryFailure(err error) bool {
+	ret := _mock.Called(err)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TryFailure")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func(error) bool); ok {
+		r0 = returnFunc(err)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// MockPromise_TryFailure_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TryFailure'
+type MockPromise_TryFailure_Call[T any] struct {
+	*mock.Call
+}
+
+// TryFailure is a helper method to define mock.On call
+//   - err error
+func (_e *MockPromise_Expecter[T]) TryFailure(err interface{}) *MockPromise_TryFailure_Call[T] {
+	return &MockPromise_TryFailure_Call[T]{Call: _e.mock.On("TryFailure", err)}
+}
+
+func (_c *MockPromise_TryFailure_Call[T]) Run(run func(err error)) *MockPromise_TryFailure_Call[T] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 error
+		if args[0] != nil {
+			arg0 = args[0].(error)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPromise_TryFailure_Call[T]) Return(b bool) *MockPromise_TryFailure_Call[T] {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *MockPromise_TryFailure_Call[T]) RunAndReturn(run func(err error) bool) *MockPromise_TryFailure_Call[T] {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TrySuccess provides a mock function for the type MockPromise
+func (_mock *MockPromise[T]) TrySuccess(v T) bool {
+	ret := _mock.Called(v)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TrySuccess")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func(T) bool); ok {
+		r0 = returnFunc(v)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// MockPromise_TrySuccess_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TrySuccess'
+type MockPromise_TrySuccess_Call[T any] struct {
+	*mock.Call
+}
+
+// TrySuccess is a helper method to define mock.On call
+//   - v T
+func (_e *MockPromise_Expecter[T]) TrySuccess(v interface{}) *MockPromise_TrySuccess_Call[T] {
+	return &MockPromise_TrySuccess_Call[T]{Call: _e.mock.On("TrySuccess", v)}
+}
+
+func (_c *MockPromise_TrySuccess_Call[T]) Run(run func(v T)) *MockPromise_TrySuccess_Call[T] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 T
+		if args[0] != nil {
+			arg0 = args[0].(T)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPromise_TrySuccess_Call[T]) Return(b bool) *MockPromise_TrySuccess_Call[T] {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *MockPromise_TrySuccess_Call[T]) RunAndReturn(run func(v T) bool) *MockPromise_TrySuccess_Call[T] {
+	_c.Call.Return(run)
+	return _c
+}
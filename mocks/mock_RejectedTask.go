@@ -0,0 +1,109 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package async
+
+import (
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockRejectedTask creates a new instance of MockRejectedTask. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRejectedTask(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRejectedTask {
+	mock := &MockRejectedTask{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockRejectedTask is an autogenerated mock type for the RejectedTask type
+type MockRejectedTask struct {
+	mock.Mock
+}
+
+type MockRejectedTask_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRejectedTask) EXPECT() *MockRejectedTask_Expecter {
+	return &MockRejectedTask_Expecter{mock: &_m.Mock}
+}
+
+// Discard provides a mock function for the type MockRejectedTask
+func (_mock *MockRejectedTask) Discard(err error) {
+	_mock.Called(err)
+	return
+}
+
+// MockRejectedTask_Discard_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Discard'
+type MockRejectedTask_Discard_Call struct {
+	*mock.Call
+}
+
+// Discard is a helper method to define mock.On call
+//   - err error
+func (_e *MockRejectedTask_Expecter) Discard(err interface{}) *MockRejectedTask_Discard_Call {
+	return &MockRejectedTask_Discard_Call{Call: _e.mock.On("Discard", err)}
+}
+
+func (_c *MockRejectedTask_Discard_Call) Run(run func(err error)) *MockRejectedTask_Discard_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 error
+		if args[0] != nil {
+			arg0 = args[0].(error)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRejectedTask_Discard_Call) Return() *MockRejectedTask_Discard_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockRejectedTask_Discard_Call) RunAndReturn(run func(err error)) *MockRejectedTask_Discard_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Run provides a mock function for the type MockRejectedTask
+func (_mock *MockRejectedTask) Run() {
+	_mock.Called()
+	return
+}
+
+// MockRejectedTask_Run_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Run'
+type MockRejectedTask_Run_Call struct {
+	*mock.Call
+}
+
+// Run is a helper method to define mock.On call
+func (_e *MockRejectedTask_Expecter) Run() *MockRejectedTask_Run_Call {
+	return &MockRejectedTask_Run_Call{Call: _e.mock.On("Run")}
+}
+
+func (_c *MockRejectedTask_Run_Call) Run(run func()) *MockRejectedTask_Run_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockRejectedTask_Run_Call) Return() *MockRejectedTask_Run_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockRejectedTask_Run_Call) RunAndReturn(run func()) *MockRejectedTask_Run_Call {
+	_c.Run(run)
+	return _c
+}
@@ -0,0 +1,406 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package async
+
+import (
+	"context"
+	"time"
+
+	"github.com/reugn/async"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockScheduledExecutorService creates a new instance of MockScheduledExecutorService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockScheduledExecutorService[T any](t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockScheduledExecutorService[T] {
+	mock := &MockScheduledExecutorService[T]{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockScheduledExecutorService is an autogenerated mock type for the ScheduledExecutorService type
+type MockScheduledExecutorService[T any] struct {
+	mock.Mock
+}
+
+type MockScheduledExecutorService_Expecter[T any] struct {
+	mock *mock.Mock
+}
+
+func (_m *MockScheduledExecutorService[T]) EXPECT() *MockScheduledExecutorService_Expecter[T] {
+	return &MockScheduledExecutorService_Expecter[T]{mock: &_m.Mock}
+}
+
+// Schedule provides a mock function for the type MockScheduledExecutorService
+func (_mock *MockScheduledExecutorService[T]) Schedule(duration time.Duration, fn func(context.Context) (T, error)) (async.Future[T], error) {
+	ret := _mock.Called(duration, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Schedule")
+	}
+
+	var r0 async.Future[T]
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(time.Duration, func(context.Context) (T, error)) (async.Future[T], error)); ok {
+		return returnFunc(duration, fn)
+	}
+	if returnFunc, ok := ret.Get(0).(func(time.Duration, func(context.Context) (T, error)) async.Future[T]); ok {
+		r0 = returnFunc(duration, fn)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(async.Future[T])
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(time.Duration, func(context.Context) (T, error)) error); ok {
+		r1 = returnFunc(duration, fn)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockScheduledExecutorService_Schedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Schedule'
+type MockScheduledExecutorService_Schedule_Call[T any] struct {
+	*mock.Call
+}
+
+// Schedule is a helper method to define mock.On call
+//   - duration time.Duration
+//   - fn func(context.Context) (T, error)
+func (_e *MockScheduledExecutorService_Expecter[T]) Schedule(duration interface{}, fn interface{}) *MockScheduledExecutorService_Schedule_Call[T] {
+	return &MockScheduledExecutorService_Schedule_Call[T]{Call: _e.mock.On("Schedule", duration, fn)}
+}
+
+func (_c *MockScheduledExecutorService_Schedule_Call[T]) Run(run func(duration time.Duration, fn func(context.Context) (T, error))) *MockScheduledExecutorService_Schedule_Call[T] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 time.Duration
+		if args[0] != nil {
+			arg0 = args[0].(time.Duration)
+		}
+		var arg1 func(context.Context) (T, error)
+		if args[1] != nil {
+			arg1 = args[1].(func(context.Context) (T, error))
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockScheduledExecutorService_Schedule_Call[T]) Return(future async.Future[T], err error) *MockScheduledExecutorService_Schedule_Call[T] {
+	_c.Call.Return(future, err)
+	return _c
+}
+
+func (_c *MockScheduledExecutorService_Schedule_Call[T]) RunAndReturn(run func(duration time.Duration, fn func(context.Context) (T, error)) (async.Future[T], error)) *MockScheduledExecutorService_Schedule_Call[T] {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ScheduleAtFixedRate provides a mock function for the type MockScheduledExecutorService
+func (_mock *MockScheduledExecutorService[T]) ScheduleAtFixedRate(initialDelay time.Duration, period time.Duration, f func(context.Context) (T, error)) (async.Future[T], error) {
+	ret := _mock.Called(initialDelay, period, f)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ScheduleAtFixedRate")
+	}
+
+	var r0 async.Future[T]
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(time.Duration, time.Duration, func(context.Context) (T, error)) (async.Future[T], error)); ok {
+		return returnFunc(initialDelay, period, f)
+	}
+	if returnFunc, ok := ret.Get(0).(func(time.Duration, time.Duration, func(context.Context) (T, error)) async.Future[T]); ok {
+		r0 = returnFunc(initialDelay, period, f)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(async.Future[T])
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(time.Duration, time.Duration, func(context.Context) (T, error)) error); ok {
+		r1 = returnFunc(initialDelay, period, f)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockScheduledExecutorService_ScheduleAtFixedRate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ScheduleAtFixedRate'
+type MockScheduledExecutorService_ScheduleAtFixedRate_Call[T any] struct {
+	*mock.Call
+}
+
+// ScheduleAtFixedRate is a helper method to define mock.On call
+//   - initialDelay time.Duration
+//   - period time.Duration
+//   - f func(context.Context) (T, error)
+func (_e *MockScheduledExecutorService_Expecter[T]) ScheduleAtFixedRate(initialDelay interface{}, period interface{}, f interface{}) *MockScheduledExecutorService_ScheduleAtFixedRate_Call[T] {
+	return &MockScheduledExecutorService_ScheduleAtFixedRate_Call[T]{Call: _e.mock.On("ScheduleAtFixedRate", initialDelay, period, f)}
+}
+
+func (_c *MockScheduledExecutorService_ScheduleAtFixedRate_Call[T]) Run(run func(initialDelay time.Duration, period time.Duration, f func(context.Context) (T, error))) *MockScheduledExecutorService_ScheduleAtFixedRate_Call[T] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 time.Duration
+		if args[0] != nil {
+			arg0 = args[0].(time.Duration)
+		}
+		var arg1 time.Duration
+		if args[1] != nil {
+			arg1 = args[1].(time.Duration)
+		}
+		var arg2 func(context.Context) (T, error)
+		if args[2] != nil {
+			arg2 = args[2].(func(context.Context) (T, error))
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockScheduledExecutorService_ScheduleAtFixedRate_Call[T]) Return(future async.Future[T], err error) *MockScheduledExecutorService_ScheduleAtFixedRate_Call[T] {
+	_c.Call.Return(future, err)
+	return _c
+}
+
+func (_c *MockScheduledExecutorService_ScheduleAtFixedRate_Call[T]) RunAndReturn(run func(initialDelay time.Duration, period time.Duration, f func(context.Context) (T, error)) (async.Future[T], error)) *MockScheduledExecutorService_ScheduleAtFixedRate_Call[T] {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ScheduleWithFixedDelay provides a mock function for the type MockScheduledExecutorService
+func (_mock *MockScheduledExecutorService[T]) ScheduleWithFixedDelay(initialDelay time.Duration, delay time.Duration, f func(context.Context) (T, error)) (async.Future[T], error) {
+	ret := _mock.Called(initialDelay, delay, f)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ScheduleWithFixedDelay")
+	}
+
+	var r0 async.Future[T]
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(time.Duration, time.Duration, func(context.Context) (T, error)) (async.Future[T], error)); ok {
+		return returnFunc(initialDelay, delay, f)
+	}
+	if returnFunc, ok := ret.Get(0).(func(time.Duration, time.Duration, func(context.Context) (T, error)) async.Future[T]); ok {
+		r0 = returnFunc(initialDelay, delay, f)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(async.Future[T])
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(time.Duration, time.Duration, func(context.Context) (T, error)) error); ok {
+		r1 = returnFunc(initialDelay, delay, f)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockScheduledExecutorService_ScheduleWithFixedDelay_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ScheduleWithFixedDelay'
+type MockScheduledExecutorService_ScheduleWithFixedDelay_Call[T any] struct {
+	*mock.Call
+}
+
+// ScheduleWithFixedDelay is a helper method to define mock.On call
+//   - initialDelay time.Duration
+//   - delay time.Duration
+//   - f func(context.Context) (T, error)
+func (_e *MockScheduledExecutorService_Expecter[T]) ScheduleWithFixedDelay(initialDelay interface{}, delay interface{}, f interface{}) *MockScheduledExecutorService_ScheduleWithFixedDelay_Call[T] {
+	return &MockScheduledExecutorService_ScheduleWithFixedDelay_Call[T]{Call: _e.mock.On("ScheduleWithFixedDelay", initialDelay, delay, f)}
+}
+
+func (_c *MockScheduledExecutorService_ScheduleWithFixedDelay_Call[T]) Run(run func(initialDelay time.Duration, delay time.Duration, f func(context.Context) (T, error))) *MockScheduledExecutorService_ScheduleWithFixedDelay_Call[T] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 time.Duration
+		if args[0] != nil {
+			arg0 = args[0].(time.Duration)
+		}
+		var arg1 time.Duration
+		if args[1] != nil {
+			arg1 = args[1].(time.Duration)
+		}
+		var arg2 func(context.Context) (T, error)
+		if args[2] != nil {
+			arg2 = args[2].(func(context.Context) (T, error))
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockScheduledExecutorService_ScheduleWithFixedDelay_Call[T]) Return(future async.Future[T], err error) *MockScheduledExecutorService_ScheduleWithFixedDelay_Call[T] {
+	_c.Call.Return(future, err)
+	return _c
+}
+
+func (_c *MockScheduledExecutorService_ScheduleWithFixedDelay_Call[T]) RunAndReturn(run func(initialDelay time.Duration, delay time.Duration, f func(context.Context) (T, error)) (async.Future[T], error)) *MockScheduledExecutorService_ScheduleWithFixedDelay_Call[T] {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Shutdown provides a mock function for the type MockScheduledExecutorService
+func (_mock *MockScheduledExecutorService[T]) Shutdown() error {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Shutdown")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func() error); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockScheduledExecutorService_Shutdown_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Shutdown'
+type MockScheduledExecutorService_Shutdown_Call[T any] struct {
+	*mock.Call
+}
+
+// Shutdown is a helper method to define mock.On call
+func (_e *MockScheduledExecutorService_Expecter[T]) Shutdown() *MockScheduledExecutorService_Shutdown_Call[T] {
+	return &MockScheduledExecutorService_Shutdown_Call[T]{Call: _e.mock.On("Shutdown")}
+}
+
+func (_c *MockScheduledExecutorService_Shutdown_Call[T]) Run(run func()) *MockScheduledExecutorService_Shutdown_Call[T] {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockScheduledExecutorService_Shutdown_Call[T]) Return(err error) *MockScheduledExecutorService_Shutdown_Call[T] {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockScheduledExecutorService_Shutdown_Call[T]) RunAndReturn(run func() error) *MockScheduledExecutorService_Shutdown_Call[T] {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Status provides a mock function for the type MockScheduledExecutorService
+func (_mock *MockScheduledExecutorService[T]) Status() async.ExecutorStatus {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Status")
+	}
+
+	var r0 async.ExecutorStatus
+	if returnFunc, ok := ret.Get(0).(func() async.ExecutorStatus); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(async.ExecutorStatus)
+	}
+	return r0
+}
+
+// MockScheduledExecutorService_Status_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Status'
+type MockScheduledExecutorService_Status_Call[T any] struct {
+	*mock.Call
+}
+
+// Status is a helper method to define mock.On call
+func (_e *MockScheduledExecutorService_Expecter[T]) Status() *MockScheduledExecutorService_Status_Call[T] {
+	return &MockScheduledExecutorService_Status_Call[T]{Call: _e.mock.On("Status")}
+}
+
+func (_c *MockScheduledExecutorService_Status_Call[T]) Run(run func()) *MockScheduledExecutorService_Status_Call[T] {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockScheduledExecutorService_Status_Call[T]) Return(executorStatus async.ExecutorStatus) *MockScheduledExecutorService_Status_Call[T] {
+	_c.Call.Return(executorStatus)
+	return _c
+}
+
+func (_c *MockScheduledExecutorService_Status_Call[T]) RunAndReturn(run func() async.ExecutorStatus) *MockScheduledExecutorService_Status_Call[T] {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Submit provides a mock function for the type MockScheduledExecutorService
+func (_mock *MockScheduledExecutorService[T]) Submit(fn func(context.Context) (T, error)) (async.Future[T], error) {
+	ret := _mock.Called(fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Submit")
+	}
+
+	var r0 async.Future[T]
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(func(context.Context) (T, error)) (async.Future[T], error)); ok {
+		return returnFunc(fn)
+	}
+	if returnFunc, ok := ret.Get(0).(func(func(context.Context) (T, error)) async.Future[T]); ok {
+		r0 = returnFunc(fn)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(async.Future[T])
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(func(context.Context) (T, error)) error); ok {
+		r1 = returnFunc(fn)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockScheduledExecutorService_Submit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Submit'
+type MockScheduledExecutorService_Submit_Call[T any] struct {
+	*mock.Call
+}
+
+// Submit is a helper method to define mock.On call
+//   - fn func(context.Context) (T, error)
+func (_e *MockScheduledExecutorService_Expecter[T]) Submit(fn interface{}) *MockScheduledExecutorService_Submit_Call[T] {
+	return &MockScheduledExecutorService_Submit_Call[T]{Call: _e.mock.On("Submit", fn)}
+}
+
+func (_c *MockScheduledExecutorService_Submit_Call[T]) Run(run func(fn func(context.Context) (T, error))) *MockScheduledExecutorService_Submit_Call[T] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 func(context.Context) (T, error)
+		if args[0] != nil {
+			arg0 = args[0].(func(context.Context) (T, error))
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockScheduledExecutorService_Submit_Call[T]) Return(future async.Future[T], err error) *MockScheduledExecutorService_Submit_Call[T] {
+	_c.Call.Return(future, err)
+	return _c
+}
+
+func (_c *MockScheduledExecutorService_Submit_Call[T]) RunAndReturn(run func(fn func(context.Context) (T, error)) (async.Future[T], error)) *MockScheduledExecutorService_Submit_Call[T] {
+	_c.Call.Return(run)
+	return _c
+}
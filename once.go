@@ -1,15 +1,11 @@
 package async
 
-import (
-	"fmt"
-	"sync"
-)
+import "sync"
 
 // Once is an object that will execute the given function exactly once.
 // Any subsequent call will return the previous result.
 type Once[T any] struct {
-	result  T
-	err     error
+	result  Result[T]
 	runOnce sync.Once
 }
 
@@ -25,16 +21,11 @@ type Once[T any] struct {
 // The return values for each subsequent call will be the result of the
 // first execution.
 //
-// If f panics, Do considers it to have returned; future calls of Do return
-// without calling f.
+// If f panics, Do considers it to have returned a [*PanicError]; future calls
+// of Do return without calling f.
 func (o *Once[T]) Do(f func() (T, error)) (T, error) {
 	o.runOnce.Do(func() {
-		defer func() {
-			if err := recover(); err != nil {
-				o.err = fmt.Errorf("recovered %v", err)
-			}
-		}()
-		o.result, o.err = f()
+		o.result = NewResult(safeCall(f))
 	})
-	return o.result, o.err
+	return o.result.Unwrap()
 }
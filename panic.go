@@ -0,0 +1,85 @@
+package async
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sync/atomic"
+)
+
+// PanicError is the error a computation is failed with when it panics.
+// It carries the recovered value and the stack trace of the panicking
+// goroutine.
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+// Error implements the error interface.
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("async: recovered panic: %v", e.Value)
+}
+
+// Unwrap returns the recovered value if it is an error.
+func (e *PanicError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+	return nil
+}
+
+// PanicHandler is a function invoked with every recovered panic.
+type PanicHandler func(*PanicError)
+
+var globalPanicHandler atomic.Pointer[PanicHandler]
+
+// SetPanicHandler sets the global [PanicHandler], invoked for panics
+// recovered in Futures, Tasks, Once and executors which have no handler
+// of their own. A nil handler removes the global handler.
+func SetPanicHandler(handler PanicHandler) {
+	if handler == nil {
+		globalPanicHandler.Store(nil)
+	} else {
+		globalPanicHandler.Store(&handler)
+	}
+}
+
+// newPanicError returns a new PanicError for the recovered value, reporting
+// it to the given handler, or to the global handler if the former is nil.
+// It must be called from the deferred function recovering the panic to
+// capture the relevant stack trace.
+func newPanicError(value any, handler PanicHandler) *PanicError {
+	err := &PanicError{
+		Value: value,
+		Stack: debug.Stack(),
+	}
+	if handler == nil {
+		if global := globalPanicHandler.Load(); global != nil {
+			handler = *global
+		}
+	}
+	if handler != nil {
+		handler(err)
+	}
+	return err
+}
+
+// safeCall calls f, converting a panic into a [*PanicError].
+func safeCall[T any](f func() (T, error)) (result T, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			var zero T
+			result, err = zero, newPanicError(r, nil)
+		}
+	}()
+	return f()
+}
+
+// safeRun runs f, reporting a panic to the global handler.
+func safeRun(f func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			_ = newPanicError(r, nil)
+		}
+	}()
+	f()
+}
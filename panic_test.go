@@ -0,0 +1,143 @@
+package async_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/reugn/async"
+
+	"github.com/reugn/async/internal/assert"
+)
+
+func TestPanicError(t *testing.T) {
+	err := errors.New("error")
+	panicErr := &async.PanicError{Value: err}
+	assert.ErrorContains(t, panicErr, "recovered panic: error")
+	assert.ErrorIs(t, panicErr, err)
+
+	panicErr = &async.PanicError{Value: "value"}
+	assert.ErrorContains(t, panicErr, "recovered panic: value")
+	assert.IsNil(t, panicErr.Unwrap())
+}
+
+func TestPanicError_Task(t *testing.T) {
+	_, err := async.NewTask(func() (int, error) {
+		panic("task panic")
+	}).Call().Join()
+
+	assertPanicError(t, err, "task panic")
+}
+
+func TestPanicError_Combinators(t *testing.T) {
+	future := async.Completed(1)
+
+	_, err := future.Map(func(_ int) (int, error) {
+		panic("map panic")
+	}).Join()
+	assertPanicError(t, err, "map panic")
+
+	_, err = future.FlatMap(func(_ int) (async.Future[int], error) {
+		panic("flatMap panic")
+	}).Join()
+	assertPanicError(t, err, "flatMap panic")
+
+	_, err = async.Failed[int](errors.New("error")).
+		RecoverWithFunc(func(_ error) async.Future[int] {
+			panic("recover panic")
+		}).Join()
+	assertPanicError(t, err, "recover panic")
+
+	_, err = async.Then(future, func(_ int) (string, error) {
+		panic("then panic")
+	}).Join()
+	assertPanicError(t, err, "then panic")
+
+	// a nil Future returned by a function fails the resulting Future
+	_, err = future.FlatMap(func(_ int) (async.Future[int], error) {
+		return nil, nil
+	}).Join()
+	assert.ErrorIs(t, err, async.ErrNilFuture)
+
+	_, err = async.Failed[int](errors.New("error")).
+		RecoverWithFunc(func(_ error) async.Future[int] {
+			return nil
+		}).Join()
+	assert.ErrorIs(t, err, async.ErrNilFuture)
+
+	_, err = async.ThenFuture(future, func(_ int) (async.Future[string], error) {
+		return nil, nil
+	}).Join()
+	assert.ErrorIs(t, err, async.ErrNilFuture)
+
+	var retryErr *async.RetryError
+	_, err = async.Retry(t.Context(), async.NewRetryPolicy(2, nil),
+		func(_ context.Context) async.Future[int] {
+			return nil
+		}).Join()
+	assert.ErrorIs(t, err, async.ErrNilFuture)
+	assert.Equal(t, true, errors.As(err, &retryErr))
+	assert.Equal(t, 2, retryErr.Attempts)
+
+	// a panicking callback does not prevent other callbacks
+	var called atomic.Bool
+	p := async.NewPromise[int]()
+	p.Future().OnComplete(func(_ int, _ error) {
+		panic("callback panic")
+	})
+	p.Future().OnComplete(func(_ int, _ error) {
+		called.Store(true)
+	})
+	p.Success(1)
+	assert.Equal(t, true, called.Load())
+}
+
+func TestPanicError_Once(t *testing.T) {
+	var once async.Once[int]
+	_, err := once.Do(func() (int, error) {
+		panic("once panic")
+	})
+	assertPanicError(t, err, "once panic")
+}
+
+func TestPanicHandler(t *testing.T) {
+	var global atomic.Pointer[async.PanicError]
+	async.SetPanicHandler(func(err *async.PanicError) {
+		global.Store(err)
+	})
+	defer async.SetPanicHandler(nil)
+
+	_, _ = async.NewTask(func() (int, error) {
+		panic("global")
+	}).Call().Join()
+	assert.Equal(t, "global", global.Load().Value)
+
+	var local atomic.Pointer[async.PanicError]
+	config := async.NewExecutorConfig(1, 1)
+	config.PanicHandler = func(err *async.PanicError) {
+		local.Store(err)
+	}
+	executor := async.NewExecutor[int](t.Context(), config)
+	defer func() { _ = executor.Shutdown() }()
+
+	future, err := executor.Submit(func(_ context.Context) (int, error) {
+		panic("local")
+	})
+	assert.IsNil(t, err)
+	_, err = future.Join()
+	assertPanicError(t, err, "local")
+	assert.Equal(t, "local", local.Load().Value)
+	assert.Equal(t, "global", global.Load().Value)
+}
+
+func assertPanicError(t *testing.T, err error, value string) {
+	t.Helper()
+	var panicErr *async.PanicError
+	if !errors.As(err, &panicErr) {
+		t.Fatalf("%v is not a PanicError", err)
+	}
+	assert.Equal(t, any(value), panicErr.Value)
+	assert.Equal(t, true, strings.Contains(string(panicErr.Stack), "panic_test.go"))
+}
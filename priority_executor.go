@@ -0,0 +1,70 @@
+package async
+
+import (
+	"context"
+	"time"
+)
+
+// PriorityExecutorConfig represents the PriorityExecutor configuration.
+type PriorityExecutorConfig struct {
+	ExecutorConfig
+	// AgingInterval is the waiting time after which a queued task gains one
+	// priority level relative to the tasks submitted later, which prevents
+	// the starvation of low-priority tasks. Zero disables aging.
+	// Tasks submitted within the same interval are ordered by priority.
+	AgingInterval time.Duration
+}
+
+// NewPriorityExecutorConfig returns a new [PriorityExecutorConfig].
+// workerPoolSize must be positive and queueSize non-negative.
+func NewPriorityExecutorConfig(workerPoolSize, queueSize int,
+	agingInterval time.Duration,
+) *PriorityExecutorConfig {
+	return &PriorityExecutorConfig{
+		ExecutorConfig: *NewExecutorConfig(workerPoolSize, queueSize),
+		AgingInterval:  agingInterval,
+	}
+}
+
+// PriorityExecutor is an [Executor] executing the queued tasks in the order
+// of their priority. Tasks of the same priority are executed in the order
+// of submission. Tasks submitted using the [Executor] methods have the
+// default priority 0.
+// Under [RejectionPolicyDiscardOldest], the task at the head of the queue,
+// i.e. the one with the highest priority, is discarded.
+type PriorityExecutor[T any] struct {
+	*Executor[T]
+}
+
+var _ ExecutorService[any] = (*PriorityExecutor[any])(nil)
+
+// NewPriorityExecutor returns a new [PriorityExecutor].
+// It panics if the configured pool sizes are invalid.
+func NewPriorityExecutor[T any](ctx context.Context,
+	config *PriorityExecutorConfig,
+) *PriorityExecutor[T] {
+	order := newPriorityOrder[T](config.AgingInterval)
+	return &PriorityExecutor[T]{
+		Executor: newExecutor[T](ctx, &config.ExecutorConfig, order),
+	}
+}
+
+// SubmitWithPriority submits a function to the executor with the given
+// priority. Tasks with a higher priority are executed first.
+// If the queue is full, the task is handled according to the configured
+// [RejectionPolicy] or [RejectionHandler].
+func (e *PriorityExecutor[T]) SubmitWithPriority(priority int,
+	f func(context.Context) (T, error),
+) (Future[T], error) {
+	return e.submit(priority, f, false)
+}
+
+// SubmitContextWithPriority submits a function to the executor with the
+// given priority, blocking until there is space in the queue, the context
+// is done or the executor is shut down.
+// The context only bounds the submission; it is not passed to the function.
+func (e *PriorityExecutor[T]) SubmitContextWithPriority(ctx context.Context,
+	priority int, f func(context.Context) (T, error),
+) (Future[T], error) {
+	return e.submitContext(ctx, priority, f)
+}
@@ -0,0 +1,264 @@
+package async_test
+
+import (
+	"context"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/reugn/async"
+
+	"github.com/reugn/async/internal/assert"
+)
+
+// blockPriorityExecutor occupies the single worker of the executor until
+// the returned function is called.
+func blockPriorityExecutor(t *testing.T, executor *async.PriorityExecutor[int]) func() {
+	t.Helper()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	_, err := executor.Submit(func(_ context.Context) (int, error) {
+		close(started)
+		<-release
+		return 0, nil
+	})
+	assert.IsNil(t, err)
+	<-started
+	return func() { close(release) }
+}
+
+func TestPriorityExecutor_Order(t *testing.T) {
+	executor := async.NewPriorityExecutor[int](t.Context(),
+		async.NewPriorityExecutorConfig(1, 8, 0))
+	release := blockPriorityExecutor(t, executor)
+
+	var mtx sync.Mutex
+	var order []int
+	futures := make([]async.Future[int], 0, 5)
+	for i, priority := range []int{1, 5, 3, 5, -1} {
+		future, err := executor.SubmitWithPriority(priority,
+			func(_ context.Context) (int, error) {
+				mtx.Lock()
+				defer mtx.Unlock()
+				order = append(order, i)
+				return i, nil
+			})
+		assert.IsNil(t, err)
+		futures = append(futures, future)
+	}
+	assert.Equal(t, 5, executor.Stats().QueueDepth)
+
+	release()
+	for i, future := range futures {
+		assertFutureResult(t, i, future)
+	}
+	assert.Equal(t, []int{1, 3, 2, 0, 4}, order)
+
+	assert.IsNil(t, executor.Shutdown())
+	assert.IsNil(t, executor.AwaitTermination(t.Context()))
+}
+
+func TestPriorityExecutor_Aging(t *testing.T) {
+	executor := async.NewPriorityExecutor[int](t.Context(),
+		async.NewPriorityExecutorConfig(1, 8, time.Millisecond))
+	release := blockPriorityExecutor(t, executor)
+
+	var mtx sync.Mutex
+	var order []int
+	task := func(i int) func(context.Context) (int, error) {
+		return func(_ context.Context) (int, error) {
+			mtx.Lock()
+			defer mtx.Unlock()
+			order = append(order, i)
+			return i, nil
+		}
+	}
+
+	// the low-priority task gains more than 5 levels while waiting
+	low, err := executor.SubmitWithPriority(0, task(0))
+	assert.IsNil(t, err)
+	time.Sleep(20 * time.Millisecond)
+	high, err := executor.SubmitWithPriority(5, task(1))
+	assert.IsNil(t, err)
+
+	release()
+	assertFutureResult(t, 0, low)
+	assertFutureResult(t, 1, high)
+	assert.Equal(t, []int{0, 1}, order)
+
+	executor.ShutdownNow()
+}
+
+func TestPriorityExecutor_AgingBounds(t *testing.T) {
+	executor := async.NewPriorityExecutor[int](t.Context(),
+		async.NewPriorityExecutorConfig(1, 8, time.Hour))
+	release := blockPriorityExecutor(t, executor)
+
+	var mtx sync.Mutex
+	var order []int
+	futures := make([]async.Future[int], 0, 4)
+	for i, priority := range []int{1, math.MinInt, 3_000_000, math.MaxInt} {
+		future, err := executor.SubmitWithPriority(priority,
+			func(_ context.Context) (int, error) {
+				mtx.Lock()
+				defer mtx.Unlock()
+				order = append(order, i)
+				return i, nil
+			})
+		assert.IsNil(t, err)
+		futures = append(futures, future)
+	}
+
+	// the extreme priorities do not overflow
+	release()
+	for i, future := range futures {
+		assertFutureResult(t, i, future)
+	}
+	assert.Equal(t, []int{3, 2, 0, 1}, order)
+
+	executor.ShutdownNow()
+}
+
+func TestPriorityExecutor_QueueFull(t *testing.T) {
+	executor := async.NewPriorityExecutor[int](t.Context(),
+		async.NewPriorityExecutorConfig(1, 2, 0))
+	release := blockPriorityExecutor(t, executor)
+	defer release()
+
+	job := func(_ context.Context) (int, error) {
+		return 1, nil
+	}
+	future1, err := executor.Submit(job)
+	assert.IsNil(t, err)
+	_, err = executor.SubmitWithPriority(1, job)
+	assert.IsNil(t, err)
+
+	_, err = executor.Submit(job)
+	assert.ErrorIs(t, err, async.ErrExecutorQueueFull)
+
+	// a cancelled task releases its queue slot
+	future1.Cancel()
+	assertFutureError(t, async.ErrCancelled, future1)
+	assert.Equal(t, 1, executor.Stats().QueueDepth)
+	_, err = executor.Submit(job)
+	assert.IsNil(t, err)
+}
+
+func TestPriorityExecutor_SubmitContext(t *testing.T) {
+	config := async.NewPriorityExecutorConfig(1, 1, 0)
+	config.RejectionPolicy = async.RejectionPolicyDiscard
+	executor := async.NewPriorityExecutor[int](t.Context(), config)
+	release := blockPriorityExecutor(t, executor)
+
+	job := func(i int) func(context.Context) (int, error) {
+		return func(_ context.Context) (int, error) {
+			return i, nil
+		}
+	}
+	low, err := executor.SubmitWithPriority(1, job(1))
+	assert.IsNil(t, err)
+
+	// the rejection policy applies to the prioritized tasks
+	discarded, err := executor.SubmitWithPriority(2, job(2))
+	assert.IsNil(t, err)
+	assertFutureError(t, async.ErrExecutorTaskDiscarded, discarded)
+
+	// the blocked submission is queued once the worker is released
+	var high async.Future[int]
+	submitted := make(chan struct{})
+	go func() {
+		defer close(submitted)
+		high, err = executor.SubmitContextWithPriority(t.Context(), 3, job(3))
+	}()
+	time.Sleep(5 * time.Millisecond)
+	release()
+	<-submitted
+	assert.IsNil(t, err)
+	assertFutureResult(t, 1, low)
+	assertFutureResult(t, 3, high)
+
+	stats := executor.Stats()
+	assert.Equal(t, uint64(4), stats.Submitted)
+	assert.Equal(t, uint64(1), stats.Rejected)
+	executor.ShutdownNow()
+}
+
+func TestPriorityExecutor_Shutdown(t *testing.T) {
+	executor := async.NewPriorityExecutor[int](t.Context(),
+		async.NewPriorityExecutorConfig(1, 4, 0))
+	release := blockPriorityExecutor(t, executor)
+
+	future, err := executor.Submit(func(_ context.Context) (int, error) {
+		return 1, nil
+	})
+	assert.IsNil(t, err)
+
+	assert.IsNil(t, executor.Shutdown())
+	assert.Equal(t, async.ExecutorStatusTerminating, executor.Status())
+	_, err = executor.Submit(func(_ context.Context) (int, error) {
+		return 2, nil
+	})
+	assert.ErrorIs(t, err, async.ErrExecutorShutDown)
+
+	ctx, cancel := context.WithTimeout(t.Context(), time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, executor.AwaitTermination(ctx), context.DeadlineExceeded)
+
+	// the queued task is executed before the executor is shut down
+	release()
+	assertFutureResult(t, 1, future)
+	assert.IsNil(t, executor.AwaitTermination(t.Context()))
+	assert.Equal(t, async.ExecutorStatusShutDown, executor.Status())
+}
+
+func TestPriorityExecutor_ShutdownNow(t *testing.T) {
+	executor := async.NewPriorityExecutor[int](t.Context(),
+		async.NewPriorityExecutorConfig(1, 4, 0))
+
+	started := make(chan struct{})
+	running, err := executor.Submit(func(ctx context.Context) (int, error) {
+		close(started)
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	assert.IsNil(t, err)
+	<-started
+
+	low, err := executor.SubmitWithPriority(1, func(_ context.Context) (int, error) {
+		return 1, nil
+	})
+	assert.IsNil(t, err)
+	high, err := executor.SubmitWithPriority(2, func(_ context.Context) (int, error) {
+		return 2, nil
+	})
+	assert.IsNil(t, err)
+
+	tasks := executor.ShutdownNow()
+	assert.Equal(t, 2, len(tasks))
+	result, err := tasks[0](t.Context())
+	assert.IsNil(t, err)
+	assert.Equal(t, 2, result)
+
+	assertFutureError(t, context.Canceled, running)
+	assertFutureError(t, async.ErrExecutorShutDown, low)
+	assertFutureError(t, async.ErrExecutorShutDown, high)
+
+	assert.IsNil(t, executor.AwaitTermination(t.Context()))
+	assert.Equal(t, async.ExecutorStatusShutDown, executor.Status())
+}
+
+func TestPriorityExecutor_ContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	executor := async.NewPriorityExecutor[int](ctx,
+		async.NewPriorityExecutorConfig(2, 4, 0))
+
+	cancel()
+	assert.IsNil(t, executor.AwaitTermination(t.Context()))
+	assert.Equal(t, async.ExecutorStatusShutDown, executor.Status())
+
+	_, err := executor.Submit(func(_ context.Context) (int, error) {
+		return 1, nil
+	})
+	assert.ErrorIs(t, err, async.ErrExecutorShutDown)
+}
@@ -1,7 +1,5 @@
 package async
 
-import "sync"
-
 // Promise represents a writable, single-assignment container,
 // which completes a Future.
 type Promise[T any] interface {
@@ -11,6 +9,26 @@ type Promise[T any] interface {
 	// Failure fails the underlying Future with an error.
 	Failure(error)
 
+	// Complete completes the underlying Future with either a value
+	// or an error.
+	Complete(T, error)
+
+	// CompleteResult completes the underlying Future with either the value
+	// or the error held by the Result.
+	CompleteResult(Result[T])
+
+	// CompleteWith completes the underlying Future with the result of the
+	// given Future once it is completed.
+	CompleteWith(Future[T])
+
+	// TrySuccess completes the underlying Future with a value.
+	// Returns true if this call completed the Future.
+	TrySuccess(T) bool
+
+	// TryFailure fails the underlying Future with an error.
+	// Returns true if this call completed the Future.
+	TryFailure(error) bool
+
 	// Future returns the underlying Future.
 	Future() Future[T]
 }
@@ -18,7 +36,6 @@ type Promise[T any] interface {
 // promiseImpl implements the Promise interface.
 type promiseImpl[T any] struct {
 	future Future[T]
-	once   sync.Once
 }
 
 // Verify promiseImpl satisfies the Promise interface.
@@ -33,17 +50,44 @@ func NewPromise[T any]() Promise[T] {
 
 // Success completes the underlying Future with a given value.
 func (p *promiseImpl[T]) Success(value T) {
-	p.once.Do(func() {
-		p.future.complete(value, nil)
-	})
+	p.future.complete(value, nil)
 }
 
 // Failure fails the underlying Future with a given error.
 func (p *promiseImpl[T]) Failure(err error) {
-	p.once.Do(func() {
-		var zero T
-		p.future.complete(zero, err)
-	})
+	var zero T
+	p.future.complete(zero, err)
+}
+
+// Complete completes the underlying Future with either a given value
+// or an error.
+func (p *promiseImpl[T]) Complete(value T, err error) {
+	p.future.complete(value, err)
+}
+
+// CompleteResult completes the underlying Future with either the value
+// or the error held by the given Result.
+func (p *promiseImpl[T]) CompleteResult(result Result[T]) {
+	p.future.complete(result.Unwrap())
+}
+
+// CompleteWith completes the underlying Future with the result of the given
+// Future once it is completed.
+func (p *promiseImpl[T]) CompleteWith(future Future[T]) {
+	completeWith(p.future, future)
+}
+
+// TrySuccess completes the underlying Future with a given value.
+// Returns true if this call completed the Future.
+func (p *promiseImpl[T]) TrySuccess(value T) bool {
+	return p.future.complete(value, nil)
+}
+
+// TryFailure fails the underlying Future with a given error.
+// Returns true if this call completed the Future.
+func (p *promiseImpl[T]) TryFailure(err error) bool {
+	var zero T
+	return p.future.complete(zero, err)
 }
 
 // Future returns the underlying Future.
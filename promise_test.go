@@ -0,0 +1,63 @@
+package async_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/reugn/async"
+
+	"github.com/reugn/async/internal/assert"
+)
+
+func TestPromise_Complete(t *testing.T) {
+	p1 := async.NewPromise[int]()
+	p1.Complete(1, nil)
+	res, err := p1.Future().Join()
+	assert.Equal(t, 1, res)
+	assert.IsNil(t, err)
+
+	p2 := async.NewPromise[int]()
+	p2.Complete(1, errors.New("error"))
+	res, err = p2.Future().Join()
+	assert.Equal(t, 0, res)
+	assert.ErrorContains(t, err, "error")
+}
+
+func TestPromise_TryComplete(t *testing.T) {
+	p := async.NewPromise[int]()
+	assert.Equal(t, true, p.TrySuccess(1))
+	assert.Equal(t, false, p.TrySuccess(2))
+	assert.Equal(t, false, p.TryFailure(errors.New("error")))
+
+	res, err := p.Future().Join()
+	assert.Equal(t, 1, res)
+	assert.IsNil(t, err)
+}
+
+func TestPromise_CompleteWith(t *testing.T) {
+	source := async.NewPromise[string]()
+	p := async.NewPromise[string]()
+	p.CompleteWith(source.Future())
+	assert.Equal(t, false, p.Future().IsDone())
+
+	source.Success("ok")
+	res, err := p.Future().Join()
+	assert.Equal(t, "ok", res)
+	assert.IsNil(t, err)
+}
+
+func TestCompleted(t *testing.T) {
+	res, err := async.Completed(1).Join()
+	assert.Equal(t, 1, res)
+	assert.IsNil(t, err)
+
+	future := async.Failed[int](errors.New("error"))
+	assert.Equal(t, async.FutureStateFailed, future.State())
+	_, err = future.Join()
+	assert.ErrorContains(t, err, "error")
+
+	future = async.Failed[int](nil)
+	assert.Equal(t, async.FutureStateFailed, future.State())
+	_, err = future.Join()
+	assert.ErrorIs(t, err, async.ErrNilError)
+}
@@ -0,0 +1,30 @@
+package async
+
+// Result represents the outcome of a computation, holding either a value
+// or an error.
+type Result[T any] struct {
+	value T
+	err   error
+}
+
+// NewResult returns a new Result with the given value and error.
+// A Result with a non-nil error is considered failed, regardless
+// of its value.
+func NewResult[T any](value T, err error) Result[T] {
+	return Result[T]{value: value, err: err}
+}
+
+// Ok reports whether the Result holds a value and no error.
+func (r Result[T]) Ok() bool {
+	return r.err == nil
+}
+
+// Err returns the error of the Result, or nil if the Result is successful.
+func (r Result[T]) Err() error {
+	return r.err
+}
+
+// Unwrap returns the value and the error of the Result.
+func (r Result[T]) Unwrap() (T, error) {
+	return r.value, r.err
+}
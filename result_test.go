@@ -0,0 +1,38 @@
+package async_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/reugn/async"
+
+	"github.com/reugn/async/internal/assert"
+)
+
+func TestResult(t *testing.T) {
+	ok := async.NewResult(1, nil)
+	assert.Equal(t, true, ok.Ok())
+	assert.IsNil(t, ok.Err())
+
+	value, err := ok.Unwrap()
+	assert.Equal(t, 1, value)
+	assert.IsNil(t, err)
+
+	failed := async.NewResult(0, errors.New("error"))
+	assert.Equal(t, false, failed.Ok())
+	assert.ErrorContains(t, failed.Err(), "error")
+
+	value, err = failed.Unwrap()
+	assert.Equal(t, 0, value)
+	assert.ErrorContains(t, err, "error")
+}
+
+func TestResult_ErrorValue(t *testing.T) {
+	// a value implementing error is not mistaken for a failure
+	result := async.NewResult[error](errors.New("value"), nil)
+	assert.Equal(t, true, result.Ok())
+
+	value, err := result.Unwrap()
+	assert.ErrorContains(t, value, "value")
+	assert.IsNil(t, err)
+}
@@ -0,0 +1,250 @@
+package async
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// Backoff returns the delay before the given retry attempt, starting at 1,
+// given the previous delay, which is zero before the first retry.
+type Backoff func(attempt int, previous time.Duration) time.Duration
+
+// ConstantBackoff returns a [Backoff] that always waits for the given delay.
+func ConstantBackoff(delay time.Duration) Backoff {
+	return func(_ int, _ time.Duration) time.Duration {
+		return delay
+	}
+}
+
+// ExponentialBackoff returns a [Backoff] that doubles the delay on each
+// attempt, starting from base and capped at maxDelay.
+func ExponentialBackoff(base, maxDelay time.Duration) Backoff {
+	return func(attempt int, _ time.Duration) time.Duration {
+		delay := base
+		for i := 1; i < attempt && delay < maxDelay; i++ {
+			delay *= 2
+		}
+		return min(delay, maxDelay)
+	}
+}
+
+// DecorrelatedJitterBackoff returns a [Backoff] that picks a random delay
+// between base and three times the previous delay, capped at maxDelay.
+func DecorrelatedJitterBackoff(base, maxDelay time.Duration) Backoff {
+	return func(_ int, previous time.Duration) time.Duration {
+		upper := max(previous, base) * 3
+		if upper <= base {
+			return min(base, maxDelay)
+		}
+		return min(base+rand.N(upper-base), maxDelay)
+	}
+}
+
+// RetryPolicy represents the configuration of a [Retry] operation.
+type RetryPolicy struct {
+	// MaxAttempts limits the total number of attempts, including the first
+	// one. A non-positive value means no limit.
+	MaxAttempts int
+	// MaxElapsedTime limits the time since the first attempt after which
+	// no retries are scheduled. A non-positive value means no limit.
+	MaxElapsedTime time.Duration
+	// Backoff calculates the delay between attempts. If nil, attempts are
+	// retried without a delay.
+	Backoff Backoff
+	// Retryable reports whether a failed attempt should be retried.
+	// If nil, all errors are retried.
+	Retryable func(error) bool
+}
+
+// NewRetryPolicy returns a new [RetryPolicy].
+// maxAttempts non-positive means no limit.
+func NewRetryPolicy(maxAttempts int, backoff Backoff) *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: maxAttempts,
+		Backoff:     backoff,
+	}
+}
+
+// RetryError is the error a Future returned by [Retry] fails with when no
+// more attempts are made. Errors holds the failure of each attempt in order,
+// followed by the context error if the retry was interrupted.
+type RetryError struct {
+	// Attempts is the number of attempts made.
+	Attempts int
+	Errors   []error
+}
+
+// Error implements the error interface.
+func (e *RetryError) Error() string {
+	if len(e.Errors) == 0 {
+		return "async: retry failed"
+	}
+	return fmt.Sprintf("async: retry failed after %d attempts: %v",
+		e.Attempts, e.Errors[len(e.Errors)-1])
+}
+
+// Unwrap returns the underlying errors, allowing them to be inspected
+// using errors.Is and errors.As.
+func (e *RetryError) Unwrap() []error {
+	return e.Errors
+}
+
+// Retry returns a Future to the result of the first successful attempt
+// produced by the factory function, retrying failed attempts according to
+// the given policy. The context passed to the factory is cancelled when ctx
+// is done or the returned Future is cancelled, which stops the retries.
+// When no more attempts are made, the Future fails with a [*RetryError].
+func Retry[T any](ctx context.Context, policy *RetryPolicy,
+	factory func(context.Context) Future[T],
+) Future[T] {
+	ctx, cancel := context.WithCancelCause(ctx)
+	next := newFuture[T]()
+	next.(*futureImpl[T]).cancelFunc = func() {
+		cancel(ErrCancelled)
+	}
+	r := &retrier[T]{
+		ctx:     ctx,
+		cancel:  cancel,
+		policy:  policy,
+		factory: factory,
+		next:    next,
+		start:   time.Now(),
+	}
+	context.AfterFunc(ctx, r.interrupt)
+	r.run()
+	return next
+}
+
+// RetryTask returns a Future to the result of the first successful call
+// of the task, retrying failed calls according to the given policy.
+// See [Retry] for details.
+func RetryTask[T any](ctx context.Context, policy *RetryPolicy, task *Task[T]) Future[T] {
+	return Retry(ctx, policy, func(_ context.Context) Future[T] {
+		return task.Call()
+	})
+}
+
+// retrier holds the state of a Retry operation.
+type retrier[T any] struct {
+	ctx     context.Context
+	cancel  context.CancelCauseFunc
+	policy  *RetryPolicy
+	factory func(context.Context) Future[T]
+	next    Future[T]
+	start   time.Time
+
+	mtx      sync.Mutex
+	attempts int
+	errs     []error
+	delay    time.Duration
+	timer    *time.Timer
+	attempt  Future[T]
+}
+
+// run starts a new attempt.
+func (r *retrier[T]) run() {
+	r.mtx.Lock()
+	// the retry is interrupted once the context is done
+	if r.ctx.Err() != nil {
+		r.mtx.Unlock()
+		return
+	}
+	r.attempts++
+	attempt, err := safeCall(func() (Future[T], error) {
+		return r.factory(r.ctx), nil
+	})
+	if err == nil && attempt == nil {
+		err = ErrNilFuture
+	}
+	if err != nil {
+		attempt = Failed[T](err)
+	}
+	r.attempt = attempt
+	r.mtx.Unlock()
+
+	attempt.OnComplete(r.onComplete)
+}
+
+// onComplete handles the result of an attempt, scheduling the next one
+// if the policy allows it.
+func (r *retrier[T]) onComplete(value T, err error) {
+	if err != nil {
+		_, err = safeCall(func() (struct{}, error) {
+			return struct{}{}, r.schedule(err)
+		})
+		if err == nil {
+			return
+		}
+	}
+	r.finish(value, err)
+}
+
+// schedule records the failure of an attempt and schedules the next one.
+// Returns a non-nil error if no more attempts should be made.
+func (r *retrier[T]) schedule(err error) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if r.ctx.Err() != nil {
+		return nil
+	}
+	r.errs = append(r.errs, err)
+	if !r.retryable(err) {
+		return r.retryError()
+	}
+	if r.policy.Backoff != nil {
+		r.delay = r.policy.Backoff(len(r.errs), r.delay)
+	}
+	if r.policy.MaxElapsedTime > 0 &&
+		time.Since(r.start)+r.delay > r.policy.MaxElapsedTime {
+		return r.retryError()
+	}
+	r.timer = time.AfterFunc(r.delay, r.run)
+	return nil
+}
+
+// retryError returns the error to fail the retry with. The mutex must be held.
+func (r *retrier[T]) retryError() *RetryError {
+	return &RetryError{Attempts: r.attempts, Errors: r.errs}
+}
+
+// retryable reports whether another attempt can be made after err.
+func (r *retrier[T]) retryable(err error) bool {
+	if r.policy.MaxAttempts > 0 && len(r.errs) >= r.policy.MaxAttempts {
+		return false
+	}
+	return r.policy.Retryable == nil || r.policy.Retryable(err)
+}
+
+// interrupt stops the retries when the context is done, cancelling the
+// attempt in progress.
+func (r *retrier[T]) interrupt() {
+	r.mtx.Lock()
+	if r.timer != nil {
+		r.timer.Stop()
+	}
+	attempt := r.attempt
+	var err error
+	if !r.next.IsDone() {
+		r.errs = append(r.errs, context.Cause(r.ctx))
+		err = r.retryError()
+	}
+	r.mtx.Unlock()
+
+	if err != nil {
+		var zero T
+		r.finish(zero, err)
+	}
+	if attempt != nil {
+		attempt.Cancel()
+	}
+}
+
+// finish completes the resulting Future and releases the context resources.
+func (r *retrier[T]) finish(value T, err error) {
+	if r.next.complete(value, err) {
+		r.cancel(nil)
+	}
+}
@@ -0,0 +1,141 @@
+package async_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/reugn/async"
+
+	"github.com/reugn/async/internal/assert"
+)
+
+func TestRetry(t *testing.T) {
+	var attempts atomic.Int32
+	factory := func(_ context.Context) async.Future[int] {
+		return async.NewTask(func() (int, error) {
+			if attempts.Add(1) < 3 {
+				return 0, errors.New("error")
+			}
+			return 1, nil
+		}).Call()
+	}
+
+	policy := async.NewRetryPolicy(5, async.ConstantBackoff(time.Millisecond))
+	res, err := async.Retry(t.Context(), policy, factory).Join()
+	assert.Equal(t, 1, res)
+	assert.IsNil(t, err)
+	assert.Equal(t, int32(3), attempts.Load())
+}
+
+func TestRetry_Exhausted(t *testing.T) {
+	err1 := errors.New("error1")
+	err2 := errors.New("error2")
+	var attempts atomic.Int32
+	task := async.NewTask(func() (int, error) {
+		if attempts.Add(1) == 1 {
+			return 0, err1
+		}
+		return 0, err2
+	})
+
+	policy := async.NewRetryPolicy(3, async.ExponentialBackoff(time.Millisecond,
+		10*time.Millisecond))
+	_, err := async.RetryTask(t.Context(), policy, task).Join()
+
+	var retryErr *async.RetryError
+	assert.Equal(t, true, errors.As(err, &retryErr))
+	assert.Equal(t, []error{err1, err2, err2}, retryErr.Errors)
+	assert.Equal(t, 3, retryErr.Attempts)
+	assert.ErrorIs(t, err, err1)
+	assert.Equal(t, int32(3), attempts.Load())
+}
+
+func TestRetry_Retryable(t *testing.T) {
+	errRetryable := errors.New("retryable")
+	errFatal := errors.New("fatal")
+	var attempts atomic.Int32
+	task := async.NewTask(func() (int, error) {
+		if attempts.Add(1) == 1 {
+			return 0, errRetryable
+		}
+		return 0, errFatal
+	})
+
+	policy := &async.RetryPolicy{
+		Backoff: async.DecorrelatedJitterBackoff(time.Millisecond, 5*time.Millisecond),
+		Retryable: func(err error) bool {
+			return errors.Is(err, errRetryable)
+		},
+	}
+	_, err := async.RetryTask(t.Context(), policy, task).Join()
+	assert.ErrorIs(t, err, errFatal)
+	assert.Equal(t, int32(2), attempts.Load())
+}
+
+func TestRetry_MaxElapsedTime(t *testing.T) {
+	task := async.NewTask(func() (int, error) {
+		return 0, errors.New("error")
+	})
+
+	policy := &async.RetryPolicy{
+		MaxElapsedTime: 20 * time.Millisecond,
+		Backoff:        async.ConstantBackoff(5 * time.Millisecond),
+	}
+	_, err := async.RetryTask(t.Context(), policy, task).Join()
+
+	var retryErr *async.RetryError
+	assert.Equal(t, true, errors.As(err, &retryErr))
+	assert.Equal(t, true, len(retryErr.Errors) <= 5)
+}
+
+func TestRetry_Cancel(t *testing.T) {
+	started := make(chan struct{}, 1)
+	factory := func(ctx context.Context) async.Future[int] {
+		p := async.NewPromise[int]()
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		context.AfterFunc(ctx, func() {
+			p.Failure(context.Cause(ctx))
+		})
+		return p.Future()
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	future := async.Retry(ctx, async.NewRetryPolicy(0, nil), factory)
+	<-started
+	cancel()
+
+	_, err := future.Join()
+	assert.ErrorIs(t, err, context.Canceled)
+	// the context error is not counted as an attempt
+	var retryErr *async.RetryError
+	assert.Equal(t, true, errors.As(err, &retryErr))
+	assert.Equal(t, 1, retryErr.Attempts)
+	assert.ErrorContains(t, err, "after 1 attempts")
+
+	future = async.Retry(t.Context(), async.NewRetryPolicy(0, nil), factory)
+	<-started
+	assert.Equal(t, true, future.Cancel())
+
+	_, err = future.Join()
+	assert.ErrorIs(t, err, async.ErrCancelled)
+}
+
+func TestBackoff(t *testing.T) {
+	exponential := async.ExponentialBackoff(time.Millisecond, 5*time.Millisecond)
+	assert.Equal(t, time.Millisecond, exponential(1, 0))
+	assert.Equal(t, 4*time.Millisecond, exponential(3, 0))
+	assert.Equal(t, 5*time.Millisecond, exponential(10, 0))
+
+	jitter := async.DecorrelatedJitterBackoff(time.Millisecond, 5*time.Millisecond)
+	var delay time.Duration
+	for i := range 10 {
+		delay = jitter(i+1, delay)
+		assert.Equal(t, true, delay >= time.Millisecond && delay <= 5*time.Millisecond)
+	}
+}
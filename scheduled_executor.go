@@ -0,0 +1,419 @@
+package async
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MissedRunPolicy defines how a [ScheduledExecutor] handles a periodic run
+// which cannot be started on time, because the previous run is still in
+// progress or the executor queue is full.
+type MissedRunPolicy uint32
+
+const (
+	// MissedRunSkip skips the missed run and waits for the next one.
+	MissedRunSkip MissedRunPolicy = iota
+	// MissedRunAbort stops the periodic task, failing its Future
+	// with [ErrMissedRun].
+	MissedRunAbort
+)
+
+// ErrMissedRun is the error a periodic task is failed with when a run is
+// missed under the [MissedRunAbort] policy.
+var ErrMissedRun = errors.New("async: scheduled run missed")
+
+// ScheduledExecutorService is an [ExecutorService] that can schedule tasks
+// to run after a delay or periodically.
+type ScheduledExecutorService[T any] interface {
+	ExecutorService[T]
+
+	// Schedule submits a function to be executed after the given delay.
+	// Cancelling the returned future cancels the scheduled execution.
+	Schedule(time.Duration, func(context.Context) (T, error)) (Future[T], error)
+
+	// ScheduleAtFixedRate submits a function to be executed periodically,
+	// first after the initial delay and then at the given period.
+	// The returned future completes only if a run fails, the executor is shut
+	// down or the future is cancelled, which stops subsequent runs.
+	ScheduleAtFixedRate(initialDelay, period time.Duration,
+		f func(context.Context) (T, error)) (Future[T], error)
+
+	// ScheduleWithFixedDelay submits a function to be executed periodically,
+	// first after the initial delay and then with the given delay between
+	// the completion of one run and the start of the next.
+	// The returned future completes only if a run fails, the executor is shut
+	// down or the future is cancelled, which stops subsequent runs.
+	ScheduleWithFixedDelay(initialDelay, delay time.Duration,
+		f func(context.Context) (T, error)) (Future[T], error)
+}
+
+// ScheduledExecutorConfig represents the ScheduledExecutor configuration.
+// The rejection policy of the ExecutorConfig applies only to the tasks
+// submitted directly; a scheduled run which cannot be queued is handled
+// according to the MissedRunPolicy.
+type ScheduledExecutorConfig struct {
+	ExecutorConfig
+	MissedRunPolicy MissedRunPolicy
+}
+
+// NewScheduledExecutorConfig returns a new [ScheduledExecutorConfig].
+// workerPoolSize must be positive and queueSize non-negative.
+func NewScheduledExecutorConfig(workerPoolSize, queueSize int,
+	missedRunPolicy MissedRunPolicy,
+) *ScheduledExecutorConfig {
+	return &ScheduledExecutorConfig{
+		ExecutorConfig:  *NewExecutorConfig(workerPoolSize, queueSize),
+		MissedRunPolicy: missedRunPolicy,
+	}
+}
+
+// ScheduledExecutor implements the [ScheduledExecutorService] interface.
+// Scheduled tasks are kept in a single timer heap and submitted to the
+// underlying [Executor] worker pool when they are due.
+type ScheduledExecutor[T any] struct {
+	*Executor[T]
+	policy   MissedRunPolicy
+	mtx      sync.Mutex
+	tasks    scheduleHeap[T]
+	wakeup   chan struct{}
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+var _ ScheduledExecutorService[any] = (*ScheduledExecutor[any])(nil)
+
+// scheduledTask represents a task in the ScheduledExecutor timer heap.
+type scheduledTask[T any] struct {
+	task      func(context.Context) (T, error)
+	future    Future[T]
+	due       time.Time
+	period    time.Duration // zero for a one-shot task
+	fixedRate bool
+	index     int // index in the heap, -1 if not queued
+	running   Future[T]
+}
+
+// NewScheduledExecutor returns a new [ScheduledExecutor].
+func NewScheduledExecutor[T any](ctx context.Context,
+	config *ScheduledExecutorConfig,
+) *ScheduledExecutor[T] {
+	executor := &ScheduledExecutor[T]{
+		Executor: NewExecutor[T](ctx, &config.ExecutorConfig),
+		policy:   config.MissedRunPolicy,
+		wakeup:   make(chan struct{}, 1),
+		stop:     make(chan struct{}),
+	}
+	go executor.loop()
+	return executor
+}
+
+// Schedule submits a function to be executed after the given delay.
+func (s *ScheduledExecutor[T]) Schedule(delay time.Duration,
+	f func(context.Context) (T, error),
+) (Future[T], error) {
+	return s.schedule(f, delay, 0, false)
+}
+
+// ScheduleAtFixedRate submits a function to be executed periodically, first
+// after the initial delay and then at the given period. It panics if the
+// period is non-positive.
+func (s *ScheduledExecutor[T]) ScheduleAtFixedRate(initialDelay, period time.Duration,
+	f func(context.Context) (T, error),
+) (Future[T], error) {
+	if period <= 0 {
+		panic(fmt.Errorf("nonpositive period: %s", period))
+	}
+	return s.schedule(f, initialDelay, period, true)
+}
+
+// ScheduleWithFixedDelay submits a function to be executed periodically,
+// first after the initial delay and then with the given delay between runs.
+// It panics if the delay is non-positive.
+func (s *ScheduledExecutor[T]) ScheduleWithFixedDelay(initialDelay, delay time.Duration,
+	f func(context.Context) (T, error),
+) (Future[T], error) {
+	if delay <= 0 {
+		panic(fmt.Errorf("nonpositive delay: %s", delay))
+	}
+	return s.schedule(f, initialDelay, delay, false)
+}
+
+// Shutdown initiates a graceful shutdown of the executor.
+// Tasks which are scheduled but not yet submitted are failed with
+// [ErrExecutorShutDown], while the submitted runs are executed.
+func (s *ScheduledExecutor[T]) Shutdown() error {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+	})
+	return s.Executor.Shutdown()
+}
+
+// ShutdownNow shuts down the executor immediately.
+// Tasks which are scheduled but not yet submitted are failed with
+// [ErrExecutorShutDown], and the running tasks are cancelled.
+// See [Executor.ShutdownNow] for details.
+func (s *ScheduledExecutor[T]) ShutdownNow() []func(context.Context) (T, error) {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+	})
+	return s.Executor.ShutdownNow()
+}
+
+func (s *ScheduledExecutor[T]) schedule(f func(context.Context) (T, error),
+	delay, period time.Duration, fixedRate bool,
+) (Future[T], error) {
+	task := &scheduledTask[T]{
+		task:      f,
+		future:    newFuture[T](),
+		period:    period,
+		fixedRate: fixedRate,
+		index:     -1,
+	}
+	task.future.(*futureImpl[T]).cancelFunc = func() {
+		s.remove(task)
+	}
+
+	s.mtx.Lock()
+	if s.Status() != ExecutorStatusRunning || s.stopped() {
+		s.mtx.Unlock()
+		return nil, ErrExecutorShutDown
+	}
+	task.due = time.Now().Add(delay)
+	heap.Push(&s.tasks, task)
+	s.mtx.Unlock()
+
+	s.notify()
+	return task.future, nil
+}
+
+// loop waits for the scheduled tasks to become due and dispatches them.
+func (s *ScheduledExecutor[T]) loop() {
+	timer := time.NewTimer(time.Hour)
+	timer.Stop()
+	for {
+		var wait <-chan time.Time
+		s.mtx.Lock()
+		if len(s.tasks) > 0 {
+			timer.Reset(time.Until(s.tasks[0].due))
+			wait = timer.C
+		}
+		s.mtx.Unlock()
+
+		select {
+		case <-wait:
+			s.dispatchDue()
+		case <-s.wakeup:
+			timer.Stop()
+		case <-s.stop:
+			s.drain()
+			return
+		case <-s.ctx.Done():
+			s.drain()
+			return
+		}
+	}
+}
+
+// dispatchDue dispatches all the tasks which are due.
+func (s *ScheduledExecutor[T]) dispatchDue() {
+	now := time.Now()
+	var due []*scheduledTask[T]
+	s.mtx.Lock()
+	for len(s.tasks) > 0 && !s.tasks[0].due.After(now) {
+		due = append(due, heap.Pop(&s.tasks).(*scheduledTask[T]))
+	}
+	s.mtx.Unlock()
+
+	for _, task := range due {
+		s.dispatch(task, now)
+	}
+}
+
+// dispatch submits a due task to the underlying executor. The rejection
+// policy of the executor does not apply, so that a full queue neither blocks
+// the scheduler nor discards the task, but is handled as a missed run.
+func (s *ScheduledExecutor[T]) dispatch(task *scheduledTask[T], now time.Time) {
+	if task.period == 0 {
+		future, err := s.submit(0, task.task, true)
+		if err != nil {
+			var zero T
+			task.future.complete(zero, err)
+			return
+		}
+		s.setRunning(task, future)
+		completeWith(task.future, future)
+		return
+	}
+
+	s.mtx.Lock()
+	inProgress := task.running != nil && !task.running.IsDone()
+	s.mtx.Unlock()
+	if inProgress {
+		s.missed(task, now)
+		return
+	}
+
+	future, err := s.submit(0, task.task, true)
+	switch {
+	case errors.Is(err, ErrExecutorQueueFull):
+		s.missed(task, now)
+	case err != nil:
+		var zero T
+		task.future.complete(zero, err)
+	default:
+		s.setRunning(task, future)
+		future.OnComplete(func(_ T, err error) {
+			if err != nil {
+				// a failed run stops the periodic task
+				var zero T
+				task.future.complete(zero, err)
+			} else if !task.fixedRate {
+				s.reschedule(task, time.Now().Add(task.period))
+			}
+		})
+		if task.fixedRate {
+			s.reschedule(task, nextRun(task, now))
+		}
+	}
+}
+
+// missed applies the missed run policy to a periodic task.
+func (s *ScheduledExecutor[T]) missed(task *scheduledTask[T], now time.Time) {
+	switch s.policy {
+	case MissedRunAbort:
+		var zero T
+		task.future.complete(zero, ErrMissedRun)
+	default:
+		if task.fixedRate {
+			s.reschedule(task, nextRun(task, now))
+		} else {
+			s.reschedule(task, now.Add(task.period))
+		}
+	}
+}
+
+// nextRun returns the next run time of a fixed-rate task after now.
+func nextRun[T any](task *scheduledTask[T], now time.Time) time.Time {
+	due := task.due.Add(task.period)
+	for !due.After(now) {
+		due = due.Add(task.period)
+	}
+	return due
+}
+
+// reschedule pushes a periodic task back to the heap unless it is completed.
+// The task is failed with [ErrExecutorShutDown] if the executor is shut down.
+func (s *ScheduledExecutor[T]) reschedule(task *scheduledTask[T], due time.Time) {
+	s.mtx.Lock()
+	if task.future.IsDone() {
+		s.mtx.Unlock()
+		return
+	}
+	if s.stopped() {
+		s.mtx.Unlock()
+		var zero T
+		task.future.complete(zero, ErrExecutorShutDown)
+		return
+	}
+	task.due = due
+	heap.Push(&s.tasks, task)
+	s.mtx.Unlock()
+
+	s.notify()
+}
+
+// setRunning records the run in progress of a task.
+func (s *ScheduledExecutor[T]) setRunning(task *scheduledTask[T], future Future[T]) {
+	s.mtx.Lock()
+	task.running = future
+	s.mtx.Unlock()
+	// the task may have been cancelled while being submitted
+	if task.future.State() == FutureStateCancelled {
+		future.Cancel()
+	}
+}
+
+// remove removes a cancelled task from the heap and cancels its run
+// in progress.
+func (s *ScheduledExecutor[T]) remove(task *scheduledTask[T]) {
+	s.mtx.Lock()
+	if task.index >= 0 {
+		heap.Remove(&s.tasks, task.index)
+	}
+	running := task.running
+	s.mtx.Unlock()
+
+	if running != nil {
+		running.Cancel()
+	}
+	s.notify()
+}
+
+// drain fails all the scheduled tasks on shutdown.
+func (s *ScheduledExecutor[T]) drain() {
+	s.mtx.Lock()
+	tasks := s.tasks
+	s.tasks = nil
+	for _, task := range tasks {
+		task.index = -1
+	}
+	s.mtx.Unlock()
+
+	for _, task := range tasks {
+		var zero T
+		task.future.complete(zero, ErrExecutorShutDown)
+	}
+}
+
+// stopped reports whether the scheduling loop has been stopped.
+func (s *ScheduledExecutor[T]) stopped() bool {
+	select {
+	case <-s.stop:
+		return true
+	case <-s.ctx.Done():
+		return true
+	default:
+		return false
+	}
+}
+
+// notify wakes up the scheduling loop without blocking.
+func (s *ScheduledExecutor[T]) notify() {
+	select {
+	case s.wakeup <- struct{}{}:
+	default:
+	}
+}
+
+// scheduleHeap implements heap.Interface, ordering tasks by due time.
+type scheduleHeap[T any] []*scheduledTask[T]
+
+func (h scheduleHeap[T]) Len() int { return len(h) }
+
+func (h scheduleHeap[T]) Less(i, j int) bool { return h[i].due.Before(h[j].due) }
+
+func (h scheduleHeap[T]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *scheduleHeap[T]) Push(x any) {
+	task := x.(*scheduledTask[T])
+	task.index = len(*h)
+	*h = append(*h, task)
+}
+
+func (h *scheduleHeap[T]) Pop() any {
+	old := *h
+	n := len(old)
+	task := old[n-1]
+	old[n-1] = nil
+	task.index = -1
+	*h = old[:n-1]
+	return task
+}
@@ -0,0 +1,220 @@
+package async_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/reugn/async"
+
+	"github.com/reugn/async/internal/assert"
+)
+
+func TestScheduledExecutor_Schedule(t *testing.T) {
+	executor := async.NewScheduledExecutor[int](t.Context(),
+		async.NewScheduledExecutorConfig(2, 2, async.MissedRunSkip))
+	defer func() { _ = executor.Shutdown() }()
+
+	start := time.Now()
+	future, err := executor.Schedule(20*time.Millisecond, func(_ context.Context) (int, error) {
+		return 1, nil
+	})
+	assert.IsNil(t, err)
+	var runs atomic.Int32
+	cancelled, err := executor.Schedule(10*time.Millisecond, func(_ context.Context) (int, error) {
+		runs.Add(1)
+		return 2, nil
+	})
+	assert.IsNil(t, err)
+	assert.Equal(t, true, cancelled.Cancel())
+
+	assertFutureResult(t, 1, future)
+	assert.Equal(t, true, time.Since(start) >= 20*time.Millisecond)
+	assertFutureError(t, async.ErrCancelled, cancelled)
+	assert.Equal(t, int32(0), runs.Load())
+}
+
+func TestScheduledExecutor_FixedRate(t *testing.T) {
+	executor := async.NewScheduledExecutor[int](t.Context(),
+		async.NewScheduledExecutorConfig(2, 2, async.MissedRunSkip))
+	defer func() { _ = executor.Shutdown() }()
+
+	var runs atomic.Int32
+	future, err := executor.ScheduleAtFixedRate(0, 10*time.Millisecond,
+		func(_ context.Context) (int, error) {
+			runs.Add(1)
+			return 0, nil
+		})
+	assert.IsNil(t, err)
+
+	time.Sleep(55 * time.Millisecond)
+	assert.Equal(t, true, future.Cancel())
+	count := runs.Load()
+	assert.Equal(t, true, count >= 3 && count <= 6)
+
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, count, runs.Load())
+	assert.Equal(t, async.FutureStateCancelled, future.State())
+}
+
+func TestScheduledExecutor_FixedDelay(t *testing.T) {
+	executor := async.NewScheduledExecutor[int](t.Context(),
+		async.NewScheduledExecutorConfig(1, 1, async.MissedRunSkip))
+	defer func() { _ = executor.Shutdown() }()
+
+	var runs atomic.Int32
+	future, err := executor.ScheduleWithFixedDelay(time.Millisecond, 5*time.Millisecond,
+		func(_ context.Context) (int, error) {
+			if runs.Add(1) == 3 {
+				return 0, errors.New("run error")
+			}
+			return 0, nil
+		})
+	assert.IsNil(t, err)
+
+	// a failed run stops the periodic task
+	_, err = future.Join()
+	assert.ErrorContains(t, err, "run error")
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, int32(3), runs.Load())
+}
+
+func TestScheduledExecutor_MissedRun(t *testing.T) {
+	executor := async.NewScheduledExecutor[int](t.Context(),
+		async.NewScheduledExecutorConfig(2, 2, async.MissedRunSkip))
+	defer func() { _ = executor.Shutdown() }()
+
+	var running, overlaps atomic.Int32
+	future, err := executor.ScheduleAtFixedRate(0, 2*time.Millisecond,
+		func(_ context.Context) (int, error) {
+			if running.Add(1) > 1 {
+				overlaps.Add(1)
+			}
+			time.Sleep(10 * time.Millisecond)
+			running.Add(-1)
+			return 0, nil
+		})
+	assert.IsNil(t, err)
+
+	time.Sleep(50 * time.Millisecond)
+	future.Cancel()
+	assert.Equal(t, int32(0), overlaps.Load())
+
+	abortExecutor := async.NewScheduledExecutor[int](t.Context(),
+		async.NewScheduledExecutorConfig(2, 2, async.MissedRunAbort))
+	defer func() { _ = abortExecutor.Shutdown() }()
+
+	future, err = abortExecutor.ScheduleAtFixedRate(0, 2*time.Millisecond,
+		func(_ context.Context) (int, error) {
+			time.Sleep(10 * time.Millisecond)
+			return 0, nil
+		})
+	assert.IsNil(t, err)
+	assertFutureError(t, async.ErrMissedRun, future)
+}
+
+func TestScheduledExecutor_RejectionPolicy(t *testing.T) {
+	for _, policy := range []async.RejectionPolicy{
+		async.RejectionPolicyCallerRuns,
+		async.RejectionPolicyDiscard,
+		async.RejectionPolicyDiscardOldest,
+	} {
+		config := async.NewScheduledExecutorConfig(1, 0, async.MissedRunSkip)
+		config.RejectionPolicy = policy
+		executor := async.NewScheduledExecutor[int](t.Context(), config)
+
+		started := make(chan struct{})
+		release := make(chan struct{})
+		_, err := executor.SubmitContext(t.Context(), func(_ context.Context) (int, error) {
+			close(started)
+			<-release
+			return 0, nil
+		})
+		assert.IsNil(t, err)
+		<-started
+
+		// the scheduled runs are neither run by the scheduler nor discarded
+		var runs atomic.Int32
+		future, err := executor.Schedule(0, func(_ context.Context) (int, error) {
+			runs.Add(1)
+			return 1, nil
+		})
+		assert.IsNil(t, err)
+		assertFutureError(t, async.ErrExecutorQueueFull, future)
+		periodic, err := executor.ScheduleAtFixedRate(0, time.Millisecond,
+			func(_ context.Context) (int, error) {
+				runs.Add(1)
+				return 0, nil
+			})
+		assert.IsNil(t, err)
+
+		// the missed runs are skipped until the worker is released
+		time.Sleep(10 * time.Millisecond)
+		assert.Equal(t, false, periodic.IsDone())
+		assert.Equal(t, int32(0), runs.Load())
+		close(release)
+		for runs.Load() == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		assert.Equal(t, true, periodic.Cancel())
+		executor.ShutdownNow()
+	}
+}
+
+func TestScheduledExecutor_Shutdown(t *testing.T) {
+	executor := async.NewScheduledExecutor[int](t.Context(),
+		async.NewScheduledExecutorConfig(1, 1, async.MissedRunSkip))
+
+	future, err := executor.Schedule(time.Second, func(_ context.Context) (int, error) {
+		return 1, nil
+	})
+	assert.IsNil(t, err)
+
+	started := make(chan struct{}, 1)
+	var runs atomic.Int32
+	periodic, err := executor.ScheduleWithFixedDelay(0, time.Millisecond,
+		func(_ context.Context) (int, error) {
+			runs.Add(1)
+			started <- struct{}{}
+			time.Sleep(5 * time.Millisecond)
+			return 0, nil
+		})
+	assert.IsNil(t, err)
+	<-started
+
+	// the run in progress is completed, but no further runs are scheduled
+	_ = executor.Shutdown()
+	assertFutureError(t, async.ErrExecutorShutDown, future, periodic)
+	assert.IsNil(t, executor.AwaitTermination(t.Context()))
+	assert.Equal(t, int32(1), runs.Load())
+
+	_, err = executor.Schedule(0, func(_ context.Context) (int, error) {
+		return 1, nil
+	})
+	assert.ErrorIs(t, err, async.ErrExecutorShutDown)
+}
+
+func TestScheduledExecutor_ShutdownNow(t *testing.T) {
+	executor := async.NewScheduledExecutor[int](t.Context(),
+		async.NewScheduledExecutorConfig(1, 1, async.MissedRunSkip))
+
+	started := make(chan struct{})
+	future, err := executor.Schedule(0, func(ctx context.Context) (int, error) {
+		close(started)
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	assert.IsNil(t, err)
+	scheduled, err := executor.Schedule(time.Second, func(_ context.Context) (int, error) {
+		return 1, nil
+	})
+	assert.IsNil(t, err)
+	<-started
+
+	assert.Equal(t, 0, len(executor.ShutdownNow()))
+	assertFutureError(t, context.Canceled, future)
+	assertFutureError(t, async.ErrExecutorShutDown, scheduled)
+	assert.IsNil(t, executor.AwaitTermination(t.Context()))
+}
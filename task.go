@@ -15,16 +15,12 @@ func NewTask[T any](taskFunc func() (T, error)) *Task[T] {
 
 // Call starts executing the task using a goroutine. It returns a
 // Future which can be used to retrieve the result or error of the
-// task when it is completed.
+// task when it is completed. If the task panics, the Future fails
+// with a [*PanicError].
 func (task *Task[T]) Call() Future[T] {
 	promise := NewPromise[T]()
 	go func() {
-		result, err := task.taskFunc()
-		if err == nil {
-			promise.Success(result)
-		} else {
-			promise.Failure(err)
-		}
+		promise.Complete(safeCall(task.taskFunc))
 	}()
 	return promise.Future()
 }
@@ -0,0 +1,132 @@
+package async
+
+import "sync/atomic"
+
+// Tuple2 holds the values of two zipped Futures.
+type Tuple2[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Tuple3 holds the values of three zipped Futures.
+type Tuple3[A, B, C any] struct {
+	First  A
+	Second B
+	Third  C
+}
+
+// Tuple4 holds the values of four zipped Futures.
+type Tuple4[A, B, C, D any] struct {
+	First  A
+	Second B
+	Third  C
+	Fourth D
+}
+
+// Zip2 combines two Futures of possibly different types into a single
+// Future holding a [Tuple2] of their values. The resulting Future fails
+// fast with the first error produced by any of the input Futures.
+func Zip2[A, B any](fa Future[A], fb Future[B]) Future[Tuple2[A, B]] {
+	return ZipWith2(fa, fb, func(a A, b B) (Tuple2[A, B], error) {
+		return Tuple2[A, B]{a, b}, nil
+	})
+}
+
+// Zip3 combines three Futures of possibly different types into a single
+// Future holding a [Tuple3] of their values. The resulting Future fails
+// fast with the first error produced by any of the input Futures.
+func Zip3[A, B, C any](fa Future[A], fb Future[B], fc Future[C]) Future[Tuple3[A, B, C]] {
+	return ZipWith3(fa, fb, fc, func(a A, b B, c C) (Tuple3[A, B, C], error) {
+		return Tuple3[A, B, C]{a, b, c}, nil
+	})
+}
+
+// Zip4 combines four Futures of possibly different types into a single
+// Future holding a [Tuple4] of their values. The resulting Future fails
+// fast with the first error produced by any of the input Futures.
+func Zip4[A, B, C, D any](fa Future[A], fb Future[B], fc Future[C],
+	fd Future[D],
+) Future[Tuple4[A, B, C, D]] {
+	return ZipWith4(fa, fb, fc, fd,
+		func(a A, b B, c C, d D) (Tuple4[A, B, C, D], error) {
+			return Tuple4[A, B, C, D]{a, b, c, d}, nil
+		})
+}
+
+// ZipWith2 combines the values of two Futures using the given function.
+// The resulting Future fails fast with the first error produced by any of
+// the input Futures.
+func ZipWith2[A, B, R any](fa Future[A], fb Future[B],
+	f func(A, B) (R, error),
+) Future[R] {
+	next := newFuture[R]()
+	var a A
+	var b B
+	z := newZipper(2, next, func() (R, error) { return f(a, b) })
+	fa.OnComplete(func(value A, err error) { a = value; z.done(err) })
+	fb.OnComplete(func(value B, err error) { b = value; z.done(err) })
+	return next
+}
+
+// ZipWith3 combines the values of three Futures using the given function.
+// The resulting Future fails fast with the first error produced by any of
+// the input Futures.
+func ZipWith3[A, B, C, R any](fa Future[A], fb Future[B], fc Future[C],
+	f func(A, B, C) (R, error),
+) Future[R] {
+	next := newFuture[R]()
+	var a A
+	var b B
+	var c C
+	z := newZipper(3, next, func() (R, error) { return f(a, b, c) })
+	fa.OnComplete(func(value A, err error) { a = value; z.done(err) })
+	fb.OnComplete(func(value B, err error) { b = value; z.done(err) })
+	fc.OnComplete(func(value C, err error) { c = value; z.done(err) })
+	return next
+}
+
+// ZipWith4 combines the values of four Futures using the given function.
+// The resulting Future fails fast with the first error produced by any of
+// the input Futures.
+func ZipWith4[A, B, C, D, R any](fa Future[A], fb Future[B], fc Future[C],
+	fd Future[D], f func(A, B, C, D) (R, error),
+) Future[R] {
+	next := newFuture[R]()
+	var a A
+	var b B
+	var c C
+	var d D
+	z := newZipper(4, next, func() (R, error) { return f(a, b, c, d) })
+	fa.OnComplete(func(value A, err error) { a = value; z.done(err) })
+	fb.OnComplete(func(value B, err error) { b = value; z.done(err) })
+	fc.OnComplete(func(value C, err error) { c = value; z.done(err) })
+	fd.OnComplete(func(value D, err error) { d = value; z.done(err) })
+	return next
+}
+
+// zipper completes a Future once a number of input Futures are completed
+// successfully, or fails it with the first error.
+type zipper[R any] struct {
+	remaining atomic.Int32
+	next      Future[R]
+	combine   func() (R, error)
+}
+
+func newZipper[R any](n int32, next Future[R], combine func() (R, error)) *zipper[R] {
+	z := &zipper[R]{
+		next:    next,
+		combine: combine,
+	}
+	z.remaining.Store(n)
+	return z
+}
+
+// done is called when an input Future is completed.
+func (z *zipper[R]) done(err error) {
+	if err != nil {
+		var zero R
+		z.next.complete(zero, err)
+	} else if z.remaining.Add(-1) == 0 {
+		z.next.complete(safeCall(z.combine))
+	}
+}
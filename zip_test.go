@@ -0,0 +1,73 @@
+package async_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/reugn/async"
+
+	"github.com/reugn/async/internal/assert"
+)
+
+type user struct {
+	name string
+}
+
+func TestZip(t *testing.T) {
+	userPromise := async.NewPromise[user]()
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		userPromise.Success(user{"john"})
+	}()
+	acl := async.Completed(true)
+	quota := async.Completed(10)
+
+	res2, err := async.Zip2(userPromise.Future(), acl).Join()
+	assert.IsNil(t, err)
+	assert.Equal(t, async.Tuple2[user, bool]{user{"john"}, true}, res2)
+
+	res3, err := async.Zip3(userPromise.Future(), acl, quota).Join()
+	assert.IsNil(t, err)
+	assert.Equal(t, async.Tuple3[user, bool, int]{user{"john"}, true, 10}, res3)
+
+	res4, err := async.Zip4(userPromise.Future(), acl, quota,
+		async.Completed("region")).Join()
+	assert.IsNil(t, err)
+	assert.Equal(t, async.Tuple4[user, bool, int, string]{
+		user{"john"}, true, 10, "region",
+	}, res4)
+}
+
+func TestZip_FailFast(t *testing.T) {
+	pending := async.NewPromise[user]()
+	err := errors.New("error")
+
+	_, zipErr := async.Zip3(pending.Future(), async.Failed[bool](err),
+		async.Completed(1)).Join()
+	assert.ErrorIs(t, zipErr, err)
+}
+
+func TestZipWith(t *testing.T) {
+	res, err := async.ZipWith2(async.Completed("a"), async.Completed(1),
+		func(s string, i int) (string, error) {
+			return s + strconv.Itoa(i), nil
+		}).Join()
+	assert.Equal(t, "a1", res)
+	assert.IsNil(t, err)
+
+	_, err = async.ZipWith4(async.Completed(1), async.Completed(2),
+		async.Completed(3), async.Completed(4),
+		func(_, _, _, _ int) (int, error) {
+			return 0, errors.New("combine error")
+		}).Join()
+	assert.ErrorContains(t, err, "combine error")
+
+	res3, err := async.ZipWith3(async.Completed(1), async.Completed(2.5),
+		async.Completed("x"), func(i int, f float64, s string) (string, error) {
+			return strconv.Itoa(i) + strconv.FormatFloat(f, 'f', 1, 64) + s, nil
+		}).Join()
+	assert.Equal(t, "12.5x", res3)
+	assert.IsNil(t, err)
+}